@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 // AdminService provides administrative operations for tenant registry,
@@ -14,68 +16,172 @@ type AdminService struct {
 
 // --- Tenant Registry ---
 
-// ListTenants returns all tenants in the system registry.
+// ListTenants returns the active tenants in the system registry.
 func (s *AdminService) ListTenants(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/admin/tenants", nil)
 }
 
+// ListTenantsInto is like ListTenants but decodes the response into out.
+func (s *AdminService) ListTenantsInto(ctx context.Context, out any) error {
+	raw, err := s.ListTenants(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// ListTenantsTyped returns all tenants in the system registry, including
+// suspended and other inactive ones, so the result can be narrowed with
+// FilterByStatus.
+func (s *AdminService) ListTenantsTyped(ctx context.Context) ([]TenantRegistryResponse, error) {
+	raw, err := s.http.get(ctx, "/api/admin/tenants", map[string]string{"include_inactive": "true"})
+	var tenants []TenantRegistryResponse
+	if err := decodeResult(raw, err, &tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
 // CreateTenant creates a new tenant via the admin API.
 func (s *AdminService) CreateTenant(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/admin/tenants", data)
 }
 
+// CreateTenantInto is like CreateTenant but decodes the response into out.
+func (s *AdminService) CreateTenantInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateTenant(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // GetStats returns system-wide statistics.
 func (s *AdminService) GetStats(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/admin/stats", nil)
 }
 
+// GetStatsInto is like GetStats but decodes the response into out.
+func (s *AdminService) GetStatsInto(ctx context.Context, out any) error {
+	raw, err := s.GetStats(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetTenant retrieves a specific tenant by ID from the admin registry.
 func (s *AdminService) GetTenant(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/admin/tenants/%s", tenantID), nil)
 }
 
+// GetTenantInto is like GetTenant but decodes the response into out.
+func (s *AdminService) GetTenantInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.GetTenant(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
+// GetTenantTyped retrieves a specific tenant by ID from the admin registry.
+func (s *AdminService) GetTenantTyped(ctx context.Context, tenantID string) (*TenantRegistryResponse, error) {
+	var tenant TenantRegistryResponse
+	if err := s.GetTenantInto(ctx, tenantID, &tenant); err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
 // ConfigureDatabase configures the database connection for an isolated tenant.
 func (s *AdminService) ConfigureDatabase(ctx context.Context, tenantID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/admin/tenants/%s/database", tenantID), data)
 }
 
+// ConfigureDatabaseInto is like ConfigureDatabase but decodes the response into out.
+func (s *AdminService) ConfigureDatabaseInto(ctx context.Context, tenantID string, data map[string]any, out any) error {
+	raw, err := s.ConfigureDatabase(ctx, tenantID, data)
+	return decodeResult(raw, err, out)
+}
+
 // Activate activates a suspended tenant.
 func (s *AdminService) Activate(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/admin/tenants/%s/activate", tenantID), nil)
 }
 
+// ActivateInto is like Activate but decodes the response into out.
+func (s *AdminService) ActivateInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.Activate(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
 // Suspend suspends an active tenant.
 func (s *AdminService) Suspend(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/admin/tenants/%s/suspend", tenantID), nil)
 }
 
+// SuspendInto is like Suspend but decodes the response into out.
+func (s *AdminService) SuspendInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.Suspend(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
 // TestConnection tests the database connection for a tenant.
 func (s *AdminService) TestConnection(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/admin/tenants/%s/test-connection", tenantID), nil)
 }
 
+// TestConnectionInto is like TestConnection but decodes the response into out.
+func (s *AdminService) TestConnectionInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.TestConnection(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
 // --- Actions ---
 
-// CreateAction creates a new action (hook/trigger) for an organization.
+// CreateAction creates a new action (hook/trigger) for an organization. The
+// code is sent inline in the JSON body whatever its size, as the server
+// accepts only JSON.
 func (s *AdminService) CreateAction(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/actions", orgID), data)
 }
 
+// CreateActionInto is like CreateAction but decodes the response into out.
+func (s *AdminService) CreateActionInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.CreateAction(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // ListActions returns all actions for an organization.
 func (s *AdminService) ListActions(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/actions", orgID), nil)
 }
 
+// ListActionsInto is like ListActions but decodes the response into out.
+func (s *AdminService) ListActionsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.ListActions(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // GetAction retrieves a specific action by ID.
 func (s *AdminService) GetAction(ctx context.Context, orgID, actionID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/actions/%s", orgID, actionID), nil)
 }
 
+// GetActionInto is like GetAction but decodes the response into out.
+func (s *AdminService) GetActionInto(ctx context.Context, orgID, actionID string, out any) error {
+	raw, err := s.GetAction(ctx, orgID, actionID)
+	return decodeResult(raw, err, out)
+}
+
+// GetActionTyped retrieves a specific action by ID.
+func (s *AdminService) GetActionTyped(ctx context.Context, orgID, actionID string) (*Action, error) {
+	var action Action
+	if err := s.GetActionInto(ctx, orgID, actionID, &action); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
 // UpdateAction modifies an existing action.
 func (s *AdminService) UpdateAction(ctx context.Context, orgID, actionID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/actions/%s", orgID, actionID), data)
 }
 
+// UpdateActionInto is like UpdateAction but decodes the response into out.
+func (s *AdminService) UpdateActionInto(ctx context.Context, orgID, actionID string, data map[string]any, out any) error {
+	raw, err := s.UpdateAction(ctx, orgID, actionID, data)
+	return decodeResult(raw, err, out)
+}
+
 // DeleteAction removes an action.
 func (s *AdminService) DeleteAction(ctx context.Context, orgID, actionID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/actions/%s", orgID, actionID), nil)
@@ -87,16 +193,138 @@ func (s *AdminService) TestAction(ctx context.Context, orgID, actionID string, d
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/actions/%s/test", orgID, actionID), data)
 }
 
+// TestActionInto is like TestAction but decodes the response into out.
+func (s *AdminService) TestActionInto(ctx context.Context, orgID, actionID string, data map[string]any, out any) error {
+	raw, err := s.TestAction(ctx, orgID, actionID, data)
+	return decodeResult(raw, err, out)
+}
+
 // GetActionExecutions returns execution history for a specific action.
 func (s *AdminService) GetActionExecutions(ctx context.Context, orgID, actionID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/actions/%s/executions", orgID, actionID), nil)
 }
 
+// GetActionExecutionsInto is like GetActionExecutions but decodes the response into out.
+func (s *AdminService) GetActionExecutionsInto(ctx context.Context, orgID, actionID string, out any) error {
+	raw, err := s.GetActionExecutions(ctx, orgID, actionID)
+	return decodeResult(raw, err, out)
+}
+
+// GetActionExecutionsTyped returns execution history for a specific action.
+// Use ExecutedAtTime on each execution for its timestamp.
+func (s *AdminService) GetActionExecutionsTyped(ctx context.Context, orgID, actionID string) ([]ActionExecution, error) {
+	var resp struct {
+		Executions []ActionExecution `json:"executions"`
+	}
+	if err := s.GetActionExecutionsInto(ctx, orgID, actionID, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Executions, nil
+}
+
 // GetOrgExecutions returns all action executions across an organization.
 func (s *AdminService) GetOrgExecutions(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/actions/executions", orgID), nil)
 }
 
+// GetOrgExecutionsInto is like GetOrgExecutions but decodes the response into out.
+func (s *AdminService) GetOrgExecutionsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetOrgExecutions(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
+// executionsPollInterval is how often StreamExecutions polls for new
+// executions.
+var executionsPollInterval = 2 * time.Second
+
+// executionsPageSize is the page size StreamExecutions polls with, and
+// executionsMaxPages bounds how far back one poll pages through a burst of
+// new executions.
+const (
+	executionsPageSize = 50
+	executionsMaxPages = 10
+)
+
+// StreamExecutions emits an organization's action executions as they
+// happen. The server has no streaming endpoint, so it polls the
+// organization's executions every two seconds; executions that already
+// existed when it starts are not emitted, and new ones are delivered oldest
+// first on the first channel. Both channels are closed when ctx is
+// cancelled. A failed poll is sent on the error channel, which then closes
+// along with the first; cancellation is not reported. Cancel ctx when you
+// stop reading so the polling goroutine exits.
+func (s *AdminService) StreamExecutions(ctx context.Context, orgID string) (<-chan ActionExecution, <-chan error) {
+	executions := make(chan ActionExecution)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(executions)
+		_, seen, err := s.pollExecutions(ctx, orgID, nil)
+		ticker := time.NewTicker(executionsPollInterval)
+		defer ticker.Stop()
+		for err == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var fresh []ActionExecution
+			if fresh, seen, err = s.pollExecutions(ctx, orgID, seen); err != nil {
+				break
+			}
+			for i := len(fresh) - 1; i >= 0; i-- {
+				select {
+				case executions <- fresh[i]:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		if ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return executions, errs
+}
+
+// pollExecutions fetches the newest executions of an organization, newest
+// first, paging back until it reaches one in seen. It returns those not in
+// seen along with the IDs to pass as seen to the next poll. With a nil seen
+// only the first page is fetched.
+func (s *AdminService) pollExecutions(ctx context.Context, orgID string, seen map[string]bool) ([]ActionExecution, map[string]bool, error) {
+	var fresh []ActionExecution
+	ids := make(map[string]bool)
+	for page := 0; page < executionsMaxPages; page++ {
+		var resp struct {
+			Executions []ActionExecution `json:"executions"`
+		}
+		raw, err := s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/actions/executions", orgID), map[string]string{
+			"limit":  strconv.Itoa(executionsPageSize),
+			"offset": strconv.Itoa(page * executionsPageSize),
+		})
+		if err := decodeResult(raw, err, &resp); err != nil {
+			return nil, nil, err
+		}
+		caughtUp := false
+		for _, e := range resp.Executions {
+			if ids[e.ID] {
+				// Shifted onto this page by executions added since the last one.
+				continue
+			}
+			ids[e.ID] = true
+			if seen[e.ID] {
+				caughtUp = true
+				continue
+			}
+			fresh = append(fresh, e)
+		}
+		if seen == nil || caughtUp || len(resp.Executions) < executionsPageSize {
+			break
+		}
+	}
+	return fresh, ids, nil
+}
+
 // --- Rate Limits ---
 
 // GetRateLimits retrieves the rate limit configuration for an organization.
@@ -104,11 +332,23 @@ func (s *AdminService) GetRateLimits(ctx context.Context, orgID string) (json.Ra
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/rate-limits", orgID), nil)
 }
 
+// GetRateLimitsInto is like GetRateLimits but decodes the response into out.
+func (s *AdminService) GetRateLimitsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetRateLimits(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateRateLimits updates the rate limit configuration for an organization.
 func (s *AdminService) UpdateRateLimits(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/rate-limits", orgID), data)
 }
 
+// UpdateRateLimitsInto is like UpdateRateLimits but decodes the response into out.
+func (s *AdminService) UpdateRateLimitsInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.UpdateRateLimits(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // --- Token Claims ---
 
 // GetTokenClaims retrieves the custom token claims configuration for an organization.
@@ -116,14 +356,32 @@ func (s *AdminService) GetTokenClaims(ctx context.Context, orgID string) (json.R
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/token-claims", orgID), nil)
 }
 
+// GetTokenClaimsInto is like GetTokenClaims but decodes the response into out.
+func (s *AdminService) GetTokenClaimsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetTokenClaims(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateTokenClaims updates the custom token claims configuration for an organization.
 func (s *AdminService) UpdateTokenClaims(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/token-claims", orgID), data)
 }
 
+// UpdateTokenClaimsInto is like UpdateTokenClaims but decodes the response into out.
+func (s *AdminService) UpdateTokenClaimsInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.UpdateTokenClaims(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // --- Health ---
 
 // Health checks whether the CoreAuth backend is healthy.
 func (s *AdminService) Health(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/health", nil)
 }
+
+// HealthInto is like Health but decodes the response into out.
+func (s *AdminService) HealthInto(ctx context.Context, out any) error {
+	raw, err := s.Health(ctx)
+	return decodeResult(raw, err, out)
+}
@@ -0,0 +1,62 @@
+package coreauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamExecutionsStopsOnCancel(t *testing.T) {
+	checkGoroutines(t)
+	defer func(d time.Duration) { executionsPollInterval = d }(executionsPollInterval)
+	executionsPollInterval = 10 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"executions":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	executions, errs := c.Admin.StreamExecutions(ctx, "org")
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	timeout := time.After(time.Second)
+	for executions != nil || errs != nil {
+		select {
+		case _, ok := <-executions:
+			if ok {
+				t.Fatal("received an execution from an empty organization")
+			}
+			executions = nil
+		case err, ok := <-errs:
+			if ok {
+				t.Fatalf("cancellation reported as error: %v", err)
+			}
+			errs = nil
+		case <-timeout:
+			t.Fatal("channels not closed after cancellation")
+		}
+	}
+}
+
+func TestGetActionExecutionsTypedDecodesList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/organizations/org/actions/act/executions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"executions":[{"id":"e1","action_id":"act","status":"success"},{"id":"e2","action_id":"act","status":"failed"}],"limit":50,"offset":0}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	executions, err := c.Admin.GetActionExecutionsTyped(context.Background(), "org", "act")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(executions) != 2 || executions[0].ID != "e1" || executions[1].Status != "failed" {
+		t.Fatalf("executions = %+v", executions)
+	}
+}
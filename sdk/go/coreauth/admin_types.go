@@ -1,5 +1,10 @@
 package coreauth
 
+import (
+	"strings"
+	"time"
+)
+
 // TenantRegistryResponse represents a tenant entry in the registry.
 type TenantRegistryResponse struct {
 	ID            string  `json:"id"`
@@ -11,6 +16,66 @@ type TenantRegistryResponse struct {
 	UpdatedAt     *string `json:"updated_at,omitempty"`
 }
 
+// Tenant registry statuses.
+const (
+	TenantStatusProvisioning = "provisioning"
+	TenantStatusActive       = "active"
+	TenantStatusSuspended    = "suspended"
+	TenantStatusDeleted      = "deleted"
+)
+
+// Tenant isolation modes.
+const (
+	IsolationModeShared    = "shared"
+	IsolationModeDedicated = "dedicated"
+)
+
+// CreatedAtTime returns CreatedAt parsed as a time, or the zero time if unset.
+func (t TenantRegistryResponse) CreatedAtTime() time.Time {
+	return parseTimestamp(t.CreatedAt)
+}
+
+// UpdatedAtTime returns UpdatedAt parsed as a time, or the zero time if unset.
+func (t TenantRegistryResponse) UpdatedAtTime() time.Time {
+	return parseTimestamp(t.UpdatedAt)
+}
+
+// FilterByStatus returns the tenants whose status is status, such as
+// TenantStatusSuspended, in their original order.
+func FilterByStatus(tenants []TenantRegistryResponse, status string) []TenantRegistryResponse {
+	var matched []TenantRegistryResponse
+	for _, t := range tenants {
+		if t.Status != nil && strings.EqualFold(*t.Status, status) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// FilterByIsolationMode returns the tenants with the given isolation mode,
+// such as IsolationModeDedicated, in their original order. A tenant without
+// an isolation mode is shared, the server's default, and "silo" is treated
+// as dedicated, as the server does.
+func FilterByIsolationMode(tenants []TenantRegistryResponse, mode string) []TenantRegistryResponse {
+	var matched []TenantRegistryResponse
+	for _, t := range tenants {
+		if strings.EqualFold(tenantIsolationMode(t), mode) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+func tenantIsolationMode(t TenantRegistryResponse) string {
+	if t.IsolationMode == nil {
+		return IsolationModeShared
+	}
+	if strings.EqualFold(*t.IsolationMode, "silo") {
+		return IsolationModeDedicated
+	}
+	return *t.IsolationMode
+}
+
 // CreateRegistryTenantRequest represents a request to create a tenant in the registry.
 type CreateRegistryTenantRequest struct {
 	Slug          string  `json:"slug"`
@@ -49,6 +114,21 @@ type Action struct {
 	UpdatedAt       *string        `json:"updated_at,omitempty"`
 }
 
+// FailureRate returns the fraction of the action's executions that failed,
+// from 0 to 1, or 0 if it has never run.
+func (a Action) FailureRate() float64 {
+	if a.TotalExecutions == nil || *a.TotalExecutions == 0 || a.TotalFailures == nil {
+		return 0
+	}
+	return float64(*a.TotalFailures) / float64(*a.TotalExecutions)
+}
+
+// LastExecutedAtTime returns LastExecutedAt parsed as a time, or the zero time
+// if unset.
+func (a Action) LastExecutedAtTime() time.Time {
+	return parseTimestamp(a.LastExecutedAt)
+}
+
 // CreateActionRequest represents a request to create an action.
 type CreateActionRequest struct {
 	Name           string         `json:"name"`
@@ -88,6 +168,12 @@ type ActionExecution struct {
 	ExecutedAt      *string        `json:"executed_at,omitempty"`
 }
 
+// ExecutedAtTime returns ExecutedAt parsed as a time, or the zero time if
+// unset.
+func (e ActionExecution) ExecutedAtTime() time.Time {
+	return parseTimestamp(e.ExecutedAt)
+}
+
 // ActionTestResponse represents the result of testing an action.
 type ActionTestResponse struct {
 	Success bool           `json:"success"`
@@ -3,7 +3,9 @@ package coreauth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // ApplicationsService provides application management, OAuth app management,
@@ -19,24 +21,54 @@ func (s *ApplicationsService) Create(ctx context.Context, data map[string]any) (
 	return s.http.post(ctx, "/api/applications", data)
 }
 
+// CreateInto is like Create but decodes the response into out.
+func (s *ApplicationsService) CreateInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.Create(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // List returns all authorization applications.
 func (s *ApplicationsService) List(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/applications", nil)
 }
 
+// ListInto is like List but decodes the response into out.
+func (s *ApplicationsService) ListInto(ctx context.Context, out any) error {
+	raw, err := s.List(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // Get retrieves an authorization application by ID.
 func (s *ApplicationsService) Get(ctx context.Context, appID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/applications/%s", appID), nil)
 }
 
+// GetInto is like Get but decodes the response into out.
+func (s *ApplicationsService) GetInto(ctx context.Context, appID string, out any) error {
+	raw, err := s.Get(ctx, appID)
+	return decodeResult(raw, err, out)
+}
+
 // Update modifies an authorization application.
 func (s *ApplicationsService) Update(ctx context.Context, appID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/applications/%s", appID), data)
 }
 
+// UpdateInto is like Update but decodes the response into out.
+func (s *ApplicationsService) UpdateInto(ctx context.Context, appID string, data map[string]any, out any) error {
+	raw, err := s.Update(ctx, appID, data)
+	return decodeResult(raw, err, out)
+}
+
 // RotateSecret rotates the client secret for an authorization application.
 func (s *ApplicationsService) RotateSecret(ctx context.Context, appID string) (json.RawMessage, error) {
-	return s.http.post(ctx, fmt.Sprintf("/api/applications/%s/rotate-secret", appID), nil)
+	return s.http.post(withConflictRetry(ctx), fmt.Sprintf("/api/applications/%s/rotate-secret", appID), nil)
+}
+
+// RotateSecretInto is like RotateSecret but decodes the response into out.
+func (s *ApplicationsService) RotateSecretInto(ctx context.Context, appID string, out any) error {
+	raw, err := s.RotateSecret(ctx, appID)
+	return decodeResult(raw, err, out)
 }
 
 // Delete removes an authorization application.
@@ -50,6 +82,35 @@ func (s *ApplicationsService) Authenticate(ctx context.Context, data map[string]
 	return s.http.post(ctx, "/api/applications/authenticate", data)
 }
 
+// AuthenticateInto is like Authenticate but decodes the response into out.
+func (s *ApplicationsService) AuthenticateInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.Authenticate(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
+// AuthenticateTyped authenticates an application with its client
+// credentials and returns the issued token. With WithAutoStoreToken the
+// access token is stored on the client, so authorized calls can follow
+// immediately.
+func (s *ApplicationsService) AuthenticateTyped(ctx context.Context, req AuthenticateAppRequest) (*TokenResponse, error) {
+	raw, err := s.http.post(ctx, "/api/applications/authenticate", req)
+	if err != nil {
+		return nil, err
+	}
+	var resp TokenResponse
+	if err := decodeJSON(raw, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, &CoreAuthError{Message: "authenticate response did not include an access token"}
+	}
+	if resp.TokenType == "" {
+		resp.TokenType = "Bearer"
+	}
+	s.http.storeIssuedToken(resp.AccessToken)
+	return &resp, nil
+}
+
 // --- OAuth Applications ---
 
 // CreateOAuthApp creates a new OAuth application.
@@ -57,24 +118,70 @@ func (s *ApplicationsService) CreateOAuthApp(ctx context.Context, data map[strin
 	return s.http.post(ctx, "/api/oauth/applications", data)
 }
 
+// CreateOAuthAppInto is like CreateOAuthApp but decodes the response into out.
+func (s *ApplicationsService) CreateOAuthAppInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateOAuthApp(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
+// CreateOAuthAppTyped creates a new OAuth application and returns it with
+// its client secret, which is shown only once. The request is first checked
+// with ValidateOAuthAppRequest; if that finds problems they are returned
+// together in a *ValidationError and nothing is sent.
+func (s *ApplicationsService) CreateOAuthAppTyped(ctx context.Context, req CreateOAuthAppRequest) (*ApplicationWithSecret, error) {
+	if problems := ValidateOAuthAppRequest(req); len(problems) > 0 {
+		return nil, &ValidationError{Message: "invalid OAuth app: " + strings.Join(problems, "; ")}
+	}
+	raw, err := s.http.post(ctx, "/api/oauth/applications", req)
+	var app ApplicationWithSecret
+	if err := decodeResult(raw, err, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
 // ListOAuthApps returns all OAuth applications.
 func (s *ApplicationsService) ListOAuthApps(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/oauth/applications", nil)
 }
 
+// ListOAuthAppsInto is like ListOAuthApps but decodes the response into out.
+func (s *ApplicationsService) ListOAuthAppsInto(ctx context.Context, out any) error {
+	raw, err := s.ListOAuthApps(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetOAuthApp retrieves an OAuth application by ID.
 func (s *ApplicationsService) GetOAuthApp(ctx context.Context, appID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/oauth/applications/%s", appID), nil)
 }
 
+// GetOAuthAppInto is like GetOAuthApp but decodes the response into out.
+func (s *ApplicationsService) GetOAuthAppInto(ctx context.Context, appID string, out any) error {
+	raw, err := s.GetOAuthApp(ctx, appID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateOAuthApp modifies an OAuth application.
 func (s *ApplicationsService) UpdateOAuthApp(ctx context.Context, appID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/oauth/applications/%s", appID), data)
 }
 
+// UpdateOAuthAppInto is like UpdateOAuthApp but decodes the response into out.
+func (s *ApplicationsService) UpdateOAuthAppInto(ctx context.Context, appID string, data map[string]any, out any) error {
+	raw, err := s.UpdateOAuthApp(ctx, appID, data)
+	return decodeResult(raw, err, out)
+}
+
 // RotateOAuthSecret rotates the client secret for an OAuth application.
 func (s *ApplicationsService) RotateOAuthSecret(ctx context.Context, appID string) (json.RawMessage, error) {
-	return s.http.post(ctx, fmt.Sprintf("/api/oauth/applications/%s/rotate-secret", appID), nil)
+	return s.http.post(withConflictRetry(ctx), fmt.Sprintf("/api/oauth/applications/%s/rotate-secret", appID), nil)
+}
+
+// RotateOAuthSecretInto is like RotateOAuthSecret but decodes the response into out.
+func (s *ApplicationsService) RotateOAuthSecretInto(ctx context.Context, appID string, out any) error {
+	raw, err := s.RotateOAuthSecret(ctx, appID)
+	return decodeResult(raw, err, out)
 }
 
 // DeleteOAuthApp removes an OAuth application.
@@ -90,16 +197,71 @@ func (s *ApplicationsService) ListEmailTemplates(ctx context.Context, orgID stri
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/email-templates", orgID), nil)
 }
 
+// ListEmailTemplatesInto is like ListEmailTemplates but decodes the response into out.
+func (s *ApplicationsService) ListEmailTemplatesInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.ListEmailTemplates(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
+// ListEmailTemplatesTyped returns all email templates for an organization.
+func (s *ApplicationsService) ListEmailTemplatesTyped(ctx context.Context, orgID string) ([]EmailTemplate, error) {
+	var templates []EmailTemplate
+	if err := s.ListEmailTemplatesInto(ctx, orgID, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// ResetAllEmailTemplates deletes every customized email template of an
+// organization, reverting each to its default, and returns how many were
+// reset. It keeps going after a failed delete; failures are returned
+// together, joined with errors.Join, and each names its template type.
+func (s *ApplicationsService) ResetAllEmailTemplates(ctx context.Context, orgID string) (int, error) {
+	templates, err := s.ListEmailTemplatesTyped(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	reset := 0
+	var errs []error
+	for _, t := range templates {
+		if !t.Customized() {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := s.DeleteEmailTemplate(ctx, orgID, t.TemplateType); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t.TemplateType, err))
+			continue
+		}
+		reset++
+	}
+	return reset, errors.Join(errs...)
+}
+
 // GetEmailTemplate retrieves a specific email template.
 func (s *ApplicationsService) GetEmailTemplate(ctx context.Context, orgID, templateID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/email-templates/%s", orgID, templateID), nil)
 }
 
+// GetEmailTemplateInto is like GetEmailTemplate but decodes the response into out.
+func (s *ApplicationsService) GetEmailTemplateInto(ctx context.Context, orgID, templateID string, out any) error {
+	raw, err := s.GetEmailTemplate(ctx, orgID, templateID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateEmailTemplate updates an email template.
 func (s *ApplicationsService) UpdateEmailTemplate(ctx context.Context, orgID, templateID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/email-templates/%s", orgID, templateID), data)
 }
 
+// UpdateEmailTemplateInto is like UpdateEmailTemplate but decodes the response into out.
+func (s *ApplicationsService) UpdateEmailTemplateInto(ctx context.Context, orgID, templateID string, data map[string]any, out any) error {
+	raw, err := s.UpdateEmailTemplate(ctx, orgID, templateID, data)
+	return decodeResult(raw, err, out)
+}
+
 // DeleteEmailTemplate removes an email template, reverting to the default.
 func (s *ApplicationsService) DeleteEmailTemplate(ctx context.Context, orgID, templateID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/email-templates/%s", orgID, templateID), nil)
@@ -110,3 +272,9 @@ func (s *ApplicationsService) DeleteEmailTemplate(ctx context.Context, orgID, te
 func (s *ApplicationsService) PreviewEmailTemplate(ctx context.Context, orgID, templateID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/email-templates/%s/preview", orgID, templateID), data)
 }
+
+// PreviewEmailTemplateInto is like PreviewEmailTemplate but decodes the response into out.
+func (s *ApplicationsService) PreviewEmailTemplateInto(ctx context.Context, orgID, templateID string, data map[string]any, out any) error {
+	raw, err := s.PreviewEmailTemplate(ctx, orgID, templateID, data)
+	return decodeResult(raw, err, out)
+}
@@ -1,5 +1,11 @@
 package coreauth
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
 // Application represents an OAuth2/OIDC application.
 type Application struct {
 	ID                          string   `json:"id"`
@@ -10,10 +16,10 @@ type Application struct {
 	AppType                     *string  `json:"app_type,omitempty"`
 	ClientID                    string   `json:"client_id"`
 	CallbackURLs                []string `json:"callback_urls"`
-	LogoutURLs                  *string  `json:"logout_urls,omitempty"`
-	WebOrigins                  *string  `json:"web_origins,omitempty"`
-	GrantTypes                  *string  `json:"grant_types,omitempty"`
-	AllowedScopes               *string  `json:"allowed_scopes,omitempty"`
+	LogoutURLs                  []string `json:"logout_urls,omitempty"`
+	WebOrigins                  []string `json:"web_origins,omitempty"`
+	GrantTypes                  []string `json:"grant_types,omitempty"`
+	AllowedScopes               []string `json:"allowed_scopes,omitempty"`
 	IsEnabled                   *bool    `json:"is_enabled,omitempty"`
 	IsFirstParty                *bool    `json:"is_first_party,omitempty"`
 	AccessTokenLifetimeSeconds  *int     `json:"access_token_lifetime_seconds,omitempty"`
@@ -36,12 +42,85 @@ type CreateOAuthAppRequest struct {
 	CallbackURLs                []string `json:"callback_urls"`
 	Description                 *string  `json:"description,omitempty"`
 	LogoURL                     *string  `json:"logo_url,omitempty"`
-	LogoutURLs                  *string  `json:"logout_urls,omitempty"`
-	WebOrigins                  *string  `json:"web_origins,omitempty"`
+	LogoutURLs                  []string `json:"logout_urls,omitempty"`
+	WebOrigins                  []string `json:"web_origins,omitempty"`
 	AccessTokenLifetimeSeconds  *int     `json:"access_token_lifetime_seconds,omitempty"`
 	RefreshTokenLifetimeSeconds *int     `json:"refresh_token_lifetime_seconds,omitempty"`
-	GrantTypes                  *string  `json:"grant_types,omitempty"`
-	AllowedScopes               *string  `json:"allowed_scopes,omitempty"`
+	GrantTypes                  []string `json:"grant_types,omitempty"`
+	AllowedScopes               []string `json:"allowed_scopes,omitempty"`
+}
+
+// Application types accepted in CreateOAuthAppRequest.AppType.
+const (
+	AppTypeWeb    = "webapp"
+	AppTypeSPA    = "spa"
+	AppTypeNative = "native"
+	AppTypeM2M    = "m2m"
+)
+
+// ValidateOAuthAppRequest checks req locally and returns a description of
+// each problem found, or nil if there are none. It checks that the name is
+// set, the slug is 3 to 63 lowercase letters, digits and hyphens, AppType is
+// one of the AppType constants, every callback URL is absolute and has no
+// fragment, the grant types are ones the token endpoint accepts
+// (authorization_code, refresh_token and client_credentials) and suit the
+// app type, and token lifetimes are positive. The server may still reject a request that passes.
+func ValidateOAuthAppRequest(req CreateOAuthAppRequest) []string {
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		addf("name is required")
+	}
+	if n := len(req.Slug); n < 3 || n > 63 {
+		addf("slug must be 3 to 63 characters")
+	}
+	if strings.IndexFunc(req.Slug, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '-')
+	}) >= 0 {
+		addf("slug may only contain lowercase letters, digits and hyphens")
+	}
+	confidential := req.AppType == AppTypeWeb || req.AppType == AppTypeM2M
+	public := req.AppType == AppTypeSPA || req.AppType == AppTypeNative
+	if !confidential && !public {
+		addf("app_type %q is not one of webapp, spa, native, m2m", req.AppType)
+	}
+	if len(req.CallbackURLs) == 0 {
+		addf("at least one callback URL is required")
+	}
+	for _, cb := range req.CallbackURLs {
+		u, err := url.Parse(cb)
+		switch {
+		case err != nil || u.Scheme == "":
+			addf("callback URL %q is not an absolute URL", cb)
+		case (u.Scheme == "http" || u.Scheme == "https") && u.Host == "":
+			addf("callback URL %q has no host", cb)
+		case u.Fragment != "" || strings.HasSuffix(cb, "#"):
+			addf("callback URL %q must not contain a fragment", cb)
+		}
+	}
+	for _, g := range req.GrantTypes {
+		switch g {
+		case "authorization_code", "refresh_token":
+			if req.AppType == AppTypeM2M {
+				addf("grant type %q is not allowed for m2m apps, which only use client_credentials", g)
+			}
+		case "client_credentials":
+			if public {
+				addf("grant type client_credentials requires a confidential app (webapp or m2m)")
+			}
+		default:
+			addf("grant type %q is not supported", g)
+		}
+	}
+	if l := req.AccessTokenLifetimeSeconds; l != nil && *l <= 0 {
+		addf("access_token_lifetime_seconds must be positive")
+	}
+	if l := req.RefreshTokenLifetimeSeconds; l != nil && *l <= 0 {
+		addf("refresh_token_lifetime_seconds must be positive")
+	}
+	return problems
 }
 
 // UpdateApplicationRequest represents a request to update an application.
@@ -63,6 +142,15 @@ type EmailTemplate struct {
 	IsCustom     *bool          `json:"is_custom,omitempty"`
 	CreatedAt    *string        `json:"created_at,omitempty"`
 	UpdatedAt    *string        `json:"updated_at,omitempty"`
+	// HasCustomTemplate reports, in a ListEmailTemplates item, whether the
+	// organization has customized the template.
+	HasCustomTemplate *bool `json:"has_custom_template,omitempty"`
+}
+
+// Customized reports whether the template has been customized, as reported
+// by HasCustomTemplate or IsCustom.
+func (t EmailTemplate) Customized() bool {
+	return (t.HasCustomTemplate != nil && *t.HasCustomTemplate) || (t.IsCustom != nil && *t.IsCustom)
 }
 
 // AuthenticateAppRequest represents a request to authenticate an application via client credentials.
@@ -4,6 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
 )
 
 // AuditService provides audit log and security event operations.
@@ -16,37 +20,236 @@ func (s *AuditService) Query(ctx context.Context, params map[string]string) (jso
 	return s.http.get(ctx, "/api/audit/logs", params)
 }
 
+// QueryInto is like Query but decodes the response into out.
+func (s *AuditService) QueryInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.Query(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
+// QueryTyped retrieves audit logs matching q. It returns a *ValidationError
+// without calling the server if q filters by event type or category.
+func (s *AuditService) QueryTyped(ctx context.Context, q AuditQuery) (*AuditLogsResponse, error) {
+	params, err := q.values()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.http.getValues(ctx, "/api/audit/logs", params)
+	if err != nil {
+		return nil, err
+	}
+	var resp AuditLogsResponse
+	if err := decodeJSON(raw, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// QueryPaginator returns a Paginator over the audit logs matching params,
+// fetching pageSize logs per request. Any limit or offset in params is
+// replaced as pages advance.
+func (s *AuditService) QueryPaginator(params map[string]string, pageSize int) *Paginator[AuditLog] {
+	return NewPaginator(offsetPageFunc(pageSize, func(ctx context.Context, offset, limit int) ([]AuditLog, int, error) {
+		q := copyParams(params)
+		q["limit"] = strconv.Itoa(limit)
+		q["offset"] = strconv.Itoa(offset)
+		var page AuditLogsResponse
+		if err := s.QueryInto(ctx, q, &page); err != nil {
+			return nil, 0, err
+		}
+		return page.Logs, page.Total, nil
+	}))
+}
+
+// ListAll returns every audit log matching params, following pagination.
+func (s *AuditService) ListAll(ctx context.Context, params map[string]string) ([]AuditLog, error) {
+	return s.QueryPaginator(params, defaultPageSize).All(ctx)
+}
+
 // Get retrieves a specific audit log entry by ID.
 func (s *AuditService) Get(ctx context.Context, logID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/audit/logs/%s", logID), nil)
 }
 
+// GetInto is like Get but decodes the response into out.
+func (s *AuditService) GetInto(ctx context.Context, logID string, out any) error {
+	raw, err := s.Get(ctx, logID)
+	return decodeResult(raw, err, out)
+}
+
 // SecurityEvents returns recent security-related events.
 func (s *AuditService) SecurityEvents(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/audit/security-events", nil)
 }
 
+// SecurityEventsInto is like SecurityEvents but decodes the response into out.
+func (s *AuditService) SecurityEventsInto(ctx context.Context, out any) error {
+	raw, err := s.SecurityEvents(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// SecurityEventsTyped returns recent security events matching filter. The
+// server returns the most recent filter.Limit events; severity and time
+// range are applied to that set.
+func (s *AuditService) SecurityEventsTyped(ctx context.Context, filter SecurityEventFilter) ([]SecurityEvent, error) {
+	var params map[string]string
+	if filter.Limit > 0 {
+		params = map[string]string{"limit": strconv.Itoa(filter.Limit)}
+	}
+	raw, err := s.http.get(ctx, "/api/audit/security-events", params)
+	if err != nil {
+		return nil, err
+	}
+	var logs []AuditLog
+	if err := decodeJSON(raw, &logs); err != nil {
+		return nil, err
+	}
+	events := make([]SecurityEvent, 0, len(logs))
+	for _, l := range logs {
+		if e := newSecurityEvent(l); filter.matches(e) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
 // FailedLogins returns failed login attempts for a specific user.
 func (s *AuditService) FailedLogins(ctx context.Context, userID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/audit/failed-logins/%s", userID), nil)
 }
 
-// Export exports audit logs (typically as CSV or JSON).
+// FailedLoginsInto is like FailedLogins but decodes the response into out.
+func (s *AuditService) FailedLoginsInto(ctx context.Context, userID string, out any) error {
+	raw, err := s.FailedLogins(ctx, userID)
+	return decodeResult(raw, err, out)
+}
+
+// FailedLoginsSince returns a user's failed login attempts since the given
+// time. The server defaults to the last 24 hours when since is zero.
+func (s *AuditService) FailedLoginsSince(ctx context.Context, userID string, since time.Time) (json.RawMessage, error) {
+	return s.http.get(ctx, fmt.Sprintf("/api/audit/failed-logins/%s", userID), timeRangeParams(since, time.Time{}, "since", "", ""))
+}
+
+// Export exports audit logs (typically as CSV or JSON). The server requires
+// a date range; use ExportRange to supply one.
 func (s *AuditService) Export(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/audit/export", nil)
 }
 
+// ExportInto is like Export but decodes the response into out.
+func (s *AuditService) ExportInto(ctx context.Context, out any) error {
+	raw, err := s.Export(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// ExportRange exports the audit logs recorded between from and to. The
+// server requires both bounds.
+func (s *AuditService) ExportRange(ctx context.Context, from, to time.Time) (json.RawMessage, error) {
+	return s.http.get(ctx, "/api/audit/export", timeRangeParams(from, to, "from_date", "to_date", ""))
+}
+
+// ExportRangeInto is like ExportRange but decodes the response into out.
+func (s *AuditService) ExportRangeInto(ctx context.Context, from, to time.Time, out any) error {
+	raw, err := s.ExportRange(ctx, from, to)
+	return decodeResult(raw, err, out)
+}
+
+// ExportTo streams the audit logs recorded between from and to, as the
+// server's JSON array, to w without holding the export in memory. The server
+// may compress the export; w always receives it decompressed.
+func (s *AuditService) ExportTo(ctx context.Context, from, to time.Time, w io.Writer) error {
+	return s.http.download(ctx, "/api/audit/export", timeRangeParams(from, to, "from_date", "to_date", ""), w)
+}
+
 // Stats returns aggregate audit statistics.
 func (s *AuditService) Stats(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/audit/stats", nil)
 }
 
+// StatsInto is like Stats but decodes the response into out.
+func (s *AuditService) StatsInto(ctx context.Context, out any) error {
+	raw, err := s.Stats(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// eventTypeSampleSize is how many recent logs EventTypes inspects.
+const eventTypeSampleSize = 1000
+
+// EventTypes returns the distinct audit event types, sorted, for building
+// filter UIs. The API has no catalog endpoint, so the types are sampled from
+// the most recent 1000 logs: types that have not occurred recently, or at
+// all in this organization, are missing from the result.
+func (s *AuditService) EventTypes(ctx context.Context) ([]string, error) {
+	return s.sampleDistinct(ctx, func(l AuditLog) string { return l.EventType })
+}
+
+// EventCategories is like EventTypes but returns the distinct event
+// categories, with the same sampling limits.
+func (s *AuditService) EventCategories(ctx context.Context) ([]string, error) {
+	return s.sampleDistinct(ctx, func(l AuditLog) string {
+		if l.EventCategory == nil {
+			return ""
+		}
+		return *l.EventCategory
+	})
+}
+
+// sampleDistinct returns the sorted, non-empty values of field across the
+// most recent logs.
+func (s *AuditService) sampleDistinct(ctx context.Context, field func(AuditLog) string) ([]string, error) {
+	resp, err := s.QueryTyped(ctx, AuditQuery{Limit: eventTypeSampleSize})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	values := []string{}
+	for _, l := range resp.Logs {
+		if v := field(l); v != "" && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
 // LoginHistory returns the authenticated user's login history.
 func (s *AuditService) LoginHistory(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/login-history", nil)
 }
 
+// LoginHistoryInto is like LoginHistory but decodes the response into out.
+func (s *AuditService) LoginHistoryInto(ctx context.Context, out any) error {
+	raw, err := s.LoginHistory(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// LoginHistoryTyped returns the login attempts matching filter, newest
+// first, with each user agent summarized as a Device.
+func (s *AuditService) LoginHistoryTyped(ctx context.Context, filter LoginHistoryFilter) ([]LoginEvent, error) {
+	raw, err := s.http.get(ctx, "/api/login-history", filter.params())
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Attempts []loginAttempt `json:"attempts"`
+	}
+	if err := decodeJSON(raw, &resp); err != nil {
+		return nil, err
+	}
+	events := make([]LoginEvent, 0, len(resp.Attempts))
+	for _, a := range resp.Attempts {
+		events = append(events, newLoginEvent(a))
+	}
+	return events, nil
+}
+
 // SecurityAuditLogs returns security-focused audit logs.
 func (s *AuditService) SecurityAuditLogs(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/security/audit-logs", nil)
 }
+
+// SecurityAuditLogsInto is like SecurityAuditLogs but decodes the response into out.
+func (s *AuditService) SecurityAuditLogsInto(ctx context.Context, out any) error {
+	raw, err := s.SecurityAuditLogs(ctx)
+	return decodeResult(raw, err, out)
+}
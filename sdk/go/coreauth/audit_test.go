@@ -0,0 +1,57 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuditQueryTypedWireFormat(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"logs":[],"total":0,"limit":10,"offset":20}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	_, err := c.Audit.QueryTyped(context.Background(), AuditQuery{
+		ActorID: "u1",
+		Status:  "failure",
+		From:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Limit:   10,
+		Offset:  20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "actor_id=u1&from_date=2024-01-02T03%3A04%3A05Z&limit=10&offset=20&status=failure"
+	if query != want {
+		t.Fatalf("query = %s, want %s", query, want)
+	}
+}
+
+func TestAuditQueryTypedRejectsListFilters(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	for _, q := range []AuditQuery{
+		{EventTypes: []string{"user.login"}},
+		{EventCategories: []string{"authentication", "security"}},
+	} {
+		var verr *ValidationError
+		if _, err := c.Audit.QueryTyped(context.Background(), q); !errors.As(err, &verr) {
+			t.Fatalf("QueryTyped(%+v) error = %v, want *ValidationError", q, err)
+		}
+	}
+	if hits != 0 {
+		t.Fatalf("server called %d times", hits)
+	}
+}
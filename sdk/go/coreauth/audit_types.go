@@ -1,5 +1,13 @@
 package coreauth
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // AuditLog represents a single audit log entry.
 type AuditLog struct {
 	ID             string         `json:"id"`
@@ -21,6 +29,11 @@ type AuditLog struct {
 	CreatedAt      *string        `json:"created_at,omitempty"`
 }
 
+// CreatedAtTime returns CreatedAt parsed as a time, or the zero time if unset.
+func (l AuditLog) CreatedAtTime() time.Time {
+	return parseTimestamp(l.CreatedAt)
+}
+
 // AuditLogsResponse represents a paginated list of audit logs.
 type AuditLogsResponse struct {
 	Logs   []AuditLog `json:"logs"`
@@ -29,5 +42,309 @@ type AuditLogsResponse struct {
 	Offset int        `json:"offset"`
 }
 
+// AuditQuery filters the audit logs returned by AuditService.QueryTyped.
+// Zero values are omitted.
+type AuditQuery struct {
+	// EventTypes and EventCategories are not supported yet: the server
+	// declares them as lists but cannot parse list query parameters, so
+	// QueryTyped rejects a query that sets either. Query without them and
+	// filter the returned logs instead.
+	EventTypes      []string
+	EventCategories []string
+	ActorID         string
+	TargetID        string
+	Status          string
+	From            time.Time
+	To              time.Time
+	Limit           int
+	Offset          int
+}
+
+// values encodes the query, or returns a *ValidationError for filters the
+// server cannot parse.
+func (q AuditQuery) values() (url.Values, error) {
+	if len(q.EventTypes) > 0 {
+		return nil, &ValidationError{Field: "event_types", Message: "filtering by event type is not supported by the server"}
+	}
+	if len(q.EventCategories) > 0 {
+		return nil, &ValidationError{Field: "event_categories", Message: "filtering by event category is not supported by the server"}
+	}
+	v := url.Values{}
+	if q.ActorID != "" {
+		v.Set("actor_id", q.ActorID)
+	}
+	if q.TargetID != "" {
+		v.Set("target_id", q.TargetID)
+	}
+	if q.Status != "" {
+		v.Set("status", q.Status)
+	}
+	for k, t := range timeRangeParams(q.From, q.To, "from_date", "to_date", "") {
+		v.Set(k, t)
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	return v, nil
+}
+
 // AuditStats is a type alias for audit statistics, represented as a flexible map.
 type AuditStats = map[string]any
+
+// Security event severities, from least to most severe.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// SecurityEvent is a security-category audit log entry.
+type SecurityEvent struct {
+	ID          string
+	EventType   string
+	Severity    string
+	ActorID     string
+	ActorName   string
+	IPAddress   string
+	Status      string
+	Description string
+	Metadata    map[string]any
+	Timestamp   time.Time
+}
+
+// SecurityEventFilter narrows the events returned by SecurityEventsTyped.
+// Zero values match everything.
+type SecurityEventFilter struct {
+	// MinSeverity drops events less severe than this level.
+	MinSeverity string
+	// Since and Until bound the event timestamp, inclusive.
+	Since time.Time
+	Until time.Time
+	// Limit is the number of recent events fetched from the server before
+	// filtering (the server default is 50, the maximum 500).
+	Limit int
+}
+
+// severityRank orders severities; unknown values rank lowest.
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	}
+	return 0
+}
+
+// newSecurityEvent converts an audit log to a SecurityEvent. The severity is
+// taken from the log's "severity" metadata, and otherwise is medium for
+// failed events and low for the rest.
+func newSecurityEvent(l AuditLog) SecurityEvent {
+	e := SecurityEvent{
+		ID:        l.ID,
+		EventType: l.EventType,
+		Metadata:  l.Metadata,
+		Timestamp: parseTimestamp(l.CreatedAt),
+	}
+	if l.ActorID != nil {
+		e.ActorID = *l.ActorID
+	}
+	if l.ActorName != nil {
+		e.ActorName = *l.ActorName
+	}
+	if l.ActorIPAddress != nil {
+		e.IPAddress = *l.ActorIPAddress
+	}
+	if l.Status != nil {
+		e.Status = *l.Status
+	}
+	if l.Description != nil {
+		e.Description = *l.Description
+	}
+	if sev, ok := l.Metadata["severity"].(string); ok && sev != "" {
+		e.Severity = sev
+	} else if e.Status == "failure" || e.Status == "error" {
+		e.Severity = SeverityMedium
+	} else {
+		e.Severity = SeverityLow
+	}
+	return e
+}
+
+func (f SecurityEventFilter) matches(e SecurityEvent) bool {
+	if f.MinSeverity != "" && severityRank(e.Severity) < severityRank(f.MinSeverity) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// GroupSecurityEventsByType buckets events by event type, preserving their
+// order within each bucket.
+func GroupSecurityEventsByType(events []SecurityEvent) map[string][]SecurityEvent {
+	groups := make(map[string][]SecurityEvent)
+	for _, e := range events {
+		groups[e.EventType] = append(groups[e.EventType], e)
+	}
+	return groups
+}
+
+// LoginHistoryFilter selects the login attempts returned by
+// LoginHistoryTyped. The server requires one of UserID, TenantID, or Email.
+type LoginHistoryFilter struct {
+	UserID   string
+	TenantID string
+	Email    string
+	// Limit defaults to 50 on the server, which caps it at 100.
+	Limit  int
+	Offset int
+}
+
+func (f LoginHistoryFilter) params() map[string]string {
+	p := map[string]string{"user_id": f.UserID, "tenant_id": f.TenantID, "email": f.Email}
+	if f.Limit > 0 {
+		p["limit"] = strconv.Itoa(f.Limit)
+	}
+	if f.Offset > 0 {
+		p["offset"] = strconv.Itoa(f.Offset)
+	}
+	return p
+}
+
+// LoginEvent is a single login attempt.
+type LoginEvent struct {
+	ID            string
+	Email         string
+	Timestamp     time.Time
+	IPAddress     string
+	UserAgent     string
+	Location      string
+	Success       bool
+	FailureReason string
+	Device        Device
+}
+
+// Device is a coarse summary of the client behind a user agent.
+type Device struct {
+	// Type is "desktop", "mobile", "tablet", "bot", or "unknown".
+	Type    string
+	Browser string
+	OS      string
+}
+
+// String returns a summary such as "Chrome on macOS (desktop)".
+func (d Device) String() string {
+	return fmt.Sprintf("%s on %s (%s)", d.Browser, d.OS, d.Type)
+}
+
+// loginAttempt is a login attempt as returned by the server.
+type loginAttempt struct {
+	ID            string  `json:"id"`
+	Email         string  `json:"email"`
+	IPAddress     string  `json:"ip_address"`
+	UserAgent     *string `json:"user_agent"`
+	DeviceType    string  `json:"device_type"`
+	Browser       string  `json:"browser"`
+	OS            string  `json:"os"`
+	Successful    bool    `json:"successful"`
+	FailureReason *string `json:"failure_reason"`
+	Location      *string `json:"location"`
+	AttemptedAt   *string `json:"attempted_at"`
+}
+
+// newLoginEvent converts a login attempt to a LoginEvent, parsing the user
+// agent for any device details the server left out.
+func newLoginEvent(a loginAttempt) LoginEvent {
+	e := LoginEvent{
+		ID:        a.ID,
+		Email:     a.Email,
+		Timestamp: parseTimestamp(a.AttemptedAt),
+		IPAddress: a.IPAddress,
+		Success:   a.Successful,
+	}
+	if a.UserAgent != nil {
+		e.UserAgent = *a.UserAgent
+	}
+	if a.Location != nil {
+		e.Location = *a.Location
+	}
+	if a.FailureReason != nil {
+		e.FailureReason = *a.FailureReason
+	}
+	e.Device = ParseUserAgent(e.UserAgent)
+	if a.DeviceType != "" && !strings.EqualFold(a.DeviceType, "unknown") {
+		e.Device.Type = strings.ToLower(a.DeviceType)
+	}
+	if a.Browser != "" && !strings.EqualFold(a.Browser, "unknown") {
+		e.Device.Browser = a.Browser
+	}
+	if a.OS != "" && !strings.EqualFold(a.OS, "unknown") {
+		e.Device.OS = a.OS
+	}
+	return e
+}
+
+// ParseUserAgent derives a coarse device, browser, and OS summary from a
+// User-Agent header. Anything it does not recognize is reported as
+// "unknown" (type) or "Unknown" (browser, OS).
+func ParseUserAgent(ua string) Device {
+	d := Device{Type: "unknown", Browser: "Unknown", OS: "Unknown"}
+	if ua == "" {
+		return d
+	}
+	has := func(sub string) bool { return strings.Contains(ua, sub) }
+	lower := strings.ToLower(ua)
+
+	switch {
+	case has("Edg/"):
+		d.Browser = "Edge"
+	case has("OPR/") || has("Opera"):
+		d.Browser = "Opera"
+	case has("Firefox/"):
+		d.Browser = "Firefox"
+	case has("Chrome/") || has("CriOS/"):
+		d.Browser = "Chrome"
+	case has("Safari/"):
+		d.Browser = "Safari"
+	case strings.HasPrefix(lower, "curl/"):
+		d.Browser = "curl"
+	}
+
+	switch {
+	case has("iPhone") || has("iPad"):
+		d.OS = "iOS"
+	case has("Android"):
+		d.OS = "Android"
+	case has("Windows"):
+		d.OS = "Windows"
+	case has("Macintosh") || has("Mac OS X"):
+		d.OS = "macOS"
+	case has("Linux"):
+		d.OS = "Linux"
+	}
+
+	switch {
+	case strings.Contains(lower, "bot") || strings.Contains(lower, "crawler") || strings.Contains(lower, "spider"):
+		d.Type = "bot"
+	case has("iPad") || (has("Android") && !has("Mobile")):
+		d.Type = "tablet"
+	case has("Mobi") || has("iPhone"):
+		d.Type = "mobile"
+	case d.OS == "Windows" || d.OS == "macOS" || d.OS == "Linux":
+		d.Type = "desktop"
+	}
+	return d
+}
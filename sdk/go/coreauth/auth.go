@@ -3,7 +3,11 @@ package coreauth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 )
 
 // AuthService provides authentication and self-service identity flows.
@@ -16,52 +20,264 @@ func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (json.R
 	return s.http.post(ctx, "/api/auth/register", req)
 }
 
-// Login authenticates a user with email and password.
+// RegisterInto is like Register but decodes the response into out.
+func (s *AuthService) RegisterInto(ctx context.Context, req RegisterRequest, out any) error {
+	raw, err := s.Register(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
+// Login authenticates a user with email and password. If the user's
+// organization enforces SSO the error is a *SSORequiredError.
 func (s *AuthService) Login(ctx context.Context, req LoginRequest) (json.RawMessage, error) {
-	return s.http.post(ctx, "/api/auth/login", req)
+	raw, err := s.http.post(ctx, "/api/auth/login", req)
+	s.emitLogin(raw, err)
+	return raw, s.ssoRequired(ctx, req.Email, err)
+}
+
+// LoginInto is like Login but decodes the response into out.
+func (s *AuthService) LoginInto(ctx context.Context, req LoginRequest, out any) error {
+	raw, err := s.Login(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
+// LoginTyped is like Login but returns a typed response. With
+// WithAutoStoreToken the access token is stored on the client. If the user
+// has MFA enabled the response has Status AuthStatusMfaRequired and
+// MfaRequired set, and carries an MfaToken and the usable Methods instead of
+// tokens. If the user must first enroll in MFA, Status is
+// AuthStatusMfaEnrollmentRequired and EnrollmentToken is set.
+func (s *AuthService) LoginTyped(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
+	var resp AuthResponse
+	if err := s.LoginInto(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	s.http.storeIssuedToken(resp.AccessToken)
+	return &resp, nil
 }
 
 // LoginHierarchical authenticates a user with optional organization context.
+// If the user's organization enforces SSO the error is a *SSORequiredError.
 func (s *AuthService) LoginHierarchical(ctx context.Context, req HierarchicalLoginRequest) (json.RawMessage, error) {
-	return s.http.post(ctx, "/api/auth/login-hierarchical", req)
+	raw, err := s.http.post(ctx, "/api/auth/login-hierarchical", req)
+	s.emitLogin(raw, err)
+	return raw, s.ssoRequired(ctx, req.Email, err)
+}
+
+// LoginHierarchicalInto is like LoginHierarchical but decodes the response into out.
+func (s *AuthService) LoginHierarchicalInto(ctx context.Context, req HierarchicalLoginRequest, out any) error {
+	raw, err := s.LoginHierarchical(ctx, req)
+	return decodeResult(raw, err, out)
 }
 
 // RefreshToken exchanges a refresh token for a new access token.
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (json.RawMessage, error) {
-	return s.http.post(ctx, "/api/auth/refresh", map[string]string{"refresh_token": refreshToken})
+	raw, err := s.http.post(ctx, "/api/auth/refresh", map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		s.http.emitAuthEvent(AuthEventRefreshFailed, "", err)
+	} else {
+		s.http.emitAuthEvent(AuthEventRefresh, accessTokenFrom(raw), nil)
+	}
+	return raw, err
+}
+
+// RefreshTokenInto is like RefreshToken but decodes the response into out.
+func (s *AuthService) RefreshTokenInto(ctx context.Context, refreshToken string, out any) error {
+	raw, err := s.RefreshToken(ctx, refreshToken)
+	return decodeResult(raw, err, out)
 }
 
 // Logout invalidates the current session.
 func (s *AuthService) Logout(ctx context.Context) error {
 	_, err := s.http.post(ctx, "/api/auth/logout", nil)
+	if err == nil {
+		s.http.emitAuthEvent(AuthEventLogout, s.http.currentToken(), nil)
+	}
 	return err
 }
 
+// emitLogin reports a login that returned an access token to the
+// WithAuthEvents hook. Logins that still require MFA carry no token and are
+// not reported.
+func (s *AuthService) emitLogin(raw json.RawMessage, err error) {
+	if err != nil {
+		return
+	}
+	if token := accessTokenFrom(raw); token != "" {
+		s.http.emitAuthEvent(AuthEventLogin, token, nil)
+	}
+}
+
+// ssoRequired turns a login error reporting that SSO is enforced into a
+// *SSORequiredError listing the email's SSO providers and, with
+// WithSSORedirectURI, the URL that starts a login with the first of them.
+// Other errors are returned unchanged. A failed lookup leaves the providers
+// or the URL empty rather than hiding the original error.
+func (s *AuthService) ssoRequired(ctx context.Context, email string, err error) error {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != CodeSSORequired {
+		return err
+	}
+	ssoErr := &SSORequiredError{Email: email, Err: apiErr}
+	var check struct {
+		Providers []SsoProvider `json:"providers"`
+	}
+	scim := &ScimService{http: s.http}
+	if scim.SSOCheckInto(ctx, email, &check) != nil {
+		return ssoErr
+	}
+	ssoErr.Providers = check.Providers
+	if uri := s.http.ssoRedirectURI; uri != "" && len(check.Providers) > 0 {
+		p := check.Providers[0]
+		var login OidcLoginResponse
+		if scim.OidcLoginInto(ctx, p.TenantID, p.ID, uri, &login) == nil {
+			ssoErr.RedirectURL = login.AuthorizationURL
+		}
+	}
+	return ssoErr
+}
+
 // GetProfile retrieves the authenticated user's profile.
 func (s *AuthService) GetProfile(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/auth/me", nil)
 }
 
+// GetProfileInto is like GetProfile but decodes the response into out.
+func (s *AuthService) GetProfileInto(ctx context.Context, out any) error {
+	raw, err := s.GetProfile(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateProfile updates the authenticated user's profile.
 func (s *AuthService) UpdateProfile(ctx context.Context, req UpdateProfileRequest) (json.RawMessage, error) {
 	return s.http.patch(ctx, "/api/auth/me", req)
 }
 
+// UpdateProfileInto is like UpdateProfile but decodes the response into out.
+func (s *AuthService) UpdateProfileInto(ctx context.Context, req UpdateProfileRequest, out any) error {
+	raw, err := s.UpdateProfile(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
 // ChangePassword changes the authenticated user's password.
 func (s *AuthService) ChangePassword(ctx context.Context, req ChangePasswordRequest) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/auth/change-password", req)
 }
 
+// ChangePasswordInto is like ChangePassword but decodes the response into out.
+func (s *AuthService) ChangePasswordInto(ctx context.Context, req ChangePasswordRequest, out any) error {
+	raw, err := s.ChangePassword(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
+// ChangePasswordChecked changes the authenticated user's password after
+// checking the new password locally with ValidatePassword against policy
+// (nil for the server defaults) and rejecting a new password equal to the
+// current one. Local failures are returned as a *ValidationError without
+// contacting the server. An incorrect current password returns an error
+// matching ErrCurrentPasswordWrong.
+func (s *AuthService) ChangePasswordChecked(ctx context.Context, req ChangePasswordRequest, policy *SecuritySettings) error {
+	if req.NewPassword == req.CurrentPassword {
+		return &ValidationError{Field: "new_password", Message: "must differ from the current password"}
+	}
+	if problems := passwordProblems(req.NewPassword, policy); len(problems) > 0 {
+		return &ValidationError{Field: "new_password", Message: strings.Join(problems, "; ")}
+	}
+	_, err := s.ChangePassword(ctx, req)
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode == CodeInvalidPassword {
+		return fmt.Errorf("%w: %w", ErrCurrentPasswordWrong, err)
+	}
+	return err
+}
+
 // VerifyEmail verifies a user's email address using a verification token.
 func (s *AuthService) VerifyEmail(ctx context.Context, token string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/verify-email", map[string]string{"token": token})
 }
 
+// VerifyEmailInto is like VerifyEmail but decodes the response into out.
+func (s *AuthService) VerifyEmailInto(ctx context.Context, token string, out any) error {
+	raw, err := s.VerifyEmail(ctx, token)
+	return decodeResult(raw, err, out)
+}
+
+// VerifyEmailTyped verifies a user's email address using a verification
+// token. If the server issues a session on verification it is returned in
+// the result and, with WithAutoStoreToken, stored on the client. Expired
+// tokens return an error matching ErrVerificationTokenExpired and unknown or
+// already used tokens one matching ErrVerificationTokenInvalid.
+func (s *AuthService) VerifyEmailTyped(ctx context.Context, token string) (*VerifyEmailResult, error) {
+	var body struct {
+		Message       string `json:"message"`
+		EmailVerified *bool  `json:"email_verified"`
+		AuthResponse
+	}
+	err := s.VerifyEmailInto(ctx, token, &body)
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode {
+		case CodeTokenExpired:
+			return nil, fmt.Errorf("%w: %w", ErrVerificationTokenExpired, err)
+		case CodeInvalidToken:
+			return nil, fmt.Errorf("%w: %w", ErrVerificationTokenInvalid, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &VerifyEmailResult{
+		Verified: body.EmailVerified == nil || *body.EmailVerified,
+		Message:  body.Message,
+	}
+	if body.AccessToken != "" {
+		session := body.AuthResponse
+		result.Session = &session
+		s.http.storeIssuedToken(session.AccessToken)
+	}
+	return result, nil
+}
+
 // ResendVerification resends the email verification message.
 func (s *AuthService) ResendVerification(ctx context.Context) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/auth/resend-verification", nil)
 }
 
+// ResendVerificationInto is like ResendVerification but decodes the response into out.
+func (s *AuthService) ResendVerificationInto(ctx context.Context, out any) error {
+	raw, err := s.ResendVerification(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// ResendVerificationTyped resends the email verification message and reports
+// when another resend will be allowed. If the server rate limits the request
+// it returns a *CooldownError, matching ErrResendCooldown, carrying the wait.
+func (s *AuthService) ResendVerificationTyped(ctx context.Context) (*ResendResult, error) {
+	var body struct {
+		Message         string   `json:"message"`
+		RetryAfter      *float64 `json:"retry_after"`
+		CooldownSeconds *float64 `json:"cooldown_seconds"`
+	}
+	err := s.ResendVerificationInto(ctx, &body)
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 429 {
+		return nil, &CooldownError{RetryAfter: apiErr.RetryAfter, Err: apiErr}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &ResendResult{Message: body.Message}
+	switch {
+	case body.RetryAfter != nil:
+		result.RetryAfter = secondsToDuration(*body.RetryAfter)
+	case body.CooldownSeconds != nil:
+		result.RetryAfter = secondsToDuration(*body.CooldownSeconds)
+	}
+	if result.RetryAfter > 0 {
+		result.NextAllowedAt = time.Now().Add(result.RetryAfter)
+	}
+	return result, nil
+}
+
 // ForgotPassword initiates a password reset flow by sending a reset email.
 func (s *AuthService) ForgotPassword(ctx context.Context, tenantID, email string) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/auth/forgot-password", map[string]string{
@@ -70,11 +286,23 @@ func (s *AuthService) ForgotPassword(ctx context.Context, tenantID, email string
 	})
 }
 
+// ForgotPasswordInto is like ForgotPassword but decodes the response into out.
+func (s *AuthService) ForgotPasswordInto(ctx context.Context, tenantID, email string, out any) error {
+	raw, err := s.ForgotPassword(ctx, tenantID, email)
+	return decodeResult(raw, err, out)
+}
+
 // VerifyResetToken validates a password reset token.
 func (s *AuthService) VerifyResetToken(ctx context.Context, token string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/auth/verify-reset-token", map[string]string{"token": token})
 }
 
+// VerifyResetTokenInto is like VerifyResetToken but decodes the response into out.
+func (s *AuthService) VerifyResetTokenInto(ctx context.Context, token string, out any) error {
+	raw, err := s.VerifyResetToken(ctx, token)
+	return decodeResult(raw, err, out)
+}
+
 // ResetPassword sets a new password using a valid reset token.
 func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/auth/reset-password", map[string]string{
@@ -83,36 +311,92 @@ func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword stri
 	})
 }
 
+// ResetPasswordInto is like ResetPassword but decodes the response into out.
+func (s *AuthService) ResetPasswordInto(ctx context.Context, token, newPassword string, out any) error {
+	raw, err := s.ResetPassword(ctx, token, newPassword)
+	return decodeResult(raw, err, out)
+}
+
 // PasswordlessStart initiates a passwordless authentication flow.
 func (s *AuthService) PasswordlessStart(ctx context.Context, tenantID string, req PasswordlessStartRequest) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/passwordless/start", tenantID), req)
 }
 
+// PasswordlessStartInto is like PasswordlessStart but decodes the response into out.
+func (s *AuthService) PasswordlessStartInto(ctx context.Context, tenantID string, req PasswordlessStartRequest, out any) error {
+	raw, err := s.PasswordlessStart(ctx, tenantID, req)
+	return decodeResult(raw, err, out)
+}
+
 // PasswordlessVerify completes a passwordless authentication flow.
 func (s *AuthService) PasswordlessVerify(ctx context.Context, tenantID string, req PasswordlessVerifyRequest) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/passwordless/verify", tenantID), req)
 }
 
+// PasswordlessVerifyInto is like PasswordlessVerify but decodes the response into out.
+func (s *AuthService) PasswordlessVerifyInto(ctx context.Context, tenantID string, req PasswordlessVerifyRequest, out any) error {
+	raw, err := s.PasswordlessVerify(ctx, tenantID, req)
+	return decodeResult(raw, err, out)
+}
+
+// PasswordlessVerifyTyped is like PasswordlessVerify but returns a typed
+// response. With WithAutoStoreToken the access token is stored on the
+// client.
+func (s *AuthService) PasswordlessVerifyTyped(ctx context.Context, tenantID string, req PasswordlessVerifyRequest) (*AuthResponse, error) {
+	raw, err := s.PasswordlessVerify(ctx, tenantID, req)
+	s.emitLogin(raw, err)
+	var resp AuthResponse
+	if err := decodeResult(raw, err, &resp); err != nil {
+		return nil, err
+	}
+	s.http.storeIssuedToken(resp.AccessToken)
+	return &resp, nil
+}
+
 // PasswordlessResend resends a passwordless authentication code.
 func (s *AuthService) PasswordlessResend(ctx context.Context, tenantID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/passwordless/resend", tenantID), data)
 }
 
+// PasswordlessResendInto is like PasswordlessResend but decodes the response into out.
+func (s *AuthService) PasswordlessResendInto(ctx context.Context, tenantID string, data map[string]any, out any) error {
+	raw, err := s.PasswordlessResend(ctx, tenantID, data)
+	return decodeResult(raw, err, out)
+}
+
 // CreateLoginFlowBrowser creates a browser-based login flow.
 func (s *AuthService) CreateLoginFlowBrowser(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/login/browser", params)
 }
 
+// CreateLoginFlowBrowserInto is like CreateLoginFlowBrowser but decodes the response into out.
+func (s *AuthService) CreateLoginFlowBrowserInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.CreateLoginFlowBrowser(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
 // CreateLoginFlowAPI creates an API-based login flow.
 func (s *AuthService) CreateLoginFlowAPI(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/login/api", params)
 }
 
+// CreateLoginFlowAPIInto is like CreateLoginFlowAPI but decodes the response into out.
+func (s *AuthService) CreateLoginFlowAPIInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.CreateLoginFlowAPI(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
 // GetLoginFlow retrieves a login flow by its ID.
 func (s *AuthService) GetLoginFlow(ctx context.Context, flowID string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/login", map[string]string{"flow": flowID})
 }
 
+// GetLoginFlowInto is like GetLoginFlow but decodes the response into out.
+func (s *AuthService) GetLoginFlowInto(ctx context.Context, flowID string, out any) error {
+	raw, err := s.GetLoginFlow(ctx, flowID)
+	return decodeResult(raw, err, out)
+}
+
 // SubmitLoginFlow submits credentials to a login flow.
 func (s *AuthService) SubmitLoginFlow(ctx context.Context, flowID string, data map[string]any) (json.RawMessage, error) {
 	if data == nil {
@@ -122,21 +406,45 @@ func (s *AuthService) SubmitLoginFlow(ctx context.Context, flowID string, data m
 	return s.http.post(ctx, "/self-service/login", data)
 }
 
+// SubmitLoginFlowInto is like SubmitLoginFlow but decodes the response into out.
+func (s *AuthService) SubmitLoginFlowInto(ctx context.Context, flowID string, data map[string]any, out any) error {
+	raw, err := s.SubmitLoginFlow(ctx, flowID, data)
+	return decodeResult(raw, err, out)
+}
+
 // CreateRegistrationFlowBrowser creates a browser-based registration flow.
 func (s *AuthService) CreateRegistrationFlowBrowser(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/registration/browser", params)
 }
 
+// CreateRegistrationFlowBrowserInto is like CreateRegistrationFlowBrowser but decodes the response into out.
+func (s *AuthService) CreateRegistrationFlowBrowserInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.CreateRegistrationFlowBrowser(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
 // CreateRegistrationFlowAPI creates an API-based registration flow.
 func (s *AuthService) CreateRegistrationFlowAPI(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/registration/api", params)
 }
 
+// CreateRegistrationFlowAPIInto is like CreateRegistrationFlowAPI but decodes the response into out.
+func (s *AuthService) CreateRegistrationFlowAPIInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.CreateRegistrationFlowAPI(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
 // GetRegistrationFlow retrieves a registration flow by its ID.
 func (s *AuthService) GetRegistrationFlow(ctx context.Context, flowID string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/self-service/registration", map[string]string{"flow": flowID})
 }
 
+// GetRegistrationFlowInto is like GetRegistrationFlow but decodes the response into out.
+func (s *AuthService) GetRegistrationFlowInto(ctx context.Context, flowID string, out any) error {
+	raw, err := s.GetRegistrationFlow(ctx, flowID)
+	return decodeResult(raw, err, out)
+}
+
 // SubmitRegistrationFlow submits data to a registration flow.
 func (s *AuthService) SubmitRegistrationFlow(ctx context.Context, flowID string, data map[string]any) (json.RawMessage, error) {
 	if data == nil {
@@ -146,7 +454,71 @@ func (s *AuthService) SubmitRegistrationFlow(ctx context.Context, flowID string,
 	return s.http.post(ctx, "/self-service/registration", data)
 }
 
+// SubmitRegistrationFlowInto is like SubmitRegistrationFlow but decodes the response into out.
+func (s *AuthService) SubmitRegistrationFlowInto(ctx context.Context, flowID string, data map[string]any, out any) error {
+	raw, err := s.SubmitRegistrationFlow(ctx, flowID, data)
+	return decodeResult(raw, err, out)
+}
+
 // Whoami returns the current session information.
 func (s *AuthService) Whoami(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/sessions/whoami", nil)
 }
+
+// Me fetches the authenticated user's profile and current session
+// concurrently. If either call gets a 401, or automatic refresh has given
+// up, it fails with an error matching ErrNotAuthenticated.
+func (s *AuthService) Me(ctx context.Context) (*MeResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		me                    MeResult
+		profileErr, whoamiErr error
+		wg                    sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if profileErr = s.GetProfileInto(ctx, &me.Profile); profileErr != nil {
+			cancel()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if whoamiErr = s.WhoamiInto(ctx, &me.Session); whoamiErr != nil {
+			cancel()
+		}
+	}()
+	wg.Wait()
+	for _, err := range []error{profileErr, whoamiErr} {
+		if isUnauthenticated(err) {
+			return nil, fmt.Errorf("%w: %w", ErrNotAuthenticated, err)
+		}
+	}
+	// A failure of one call cancels the other; report the original cause.
+	for _, err := range []error{profileErr, whoamiErr} {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+	if err := errors.Join(profileErr, whoamiErr); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+// isUnauthenticated reports whether err means the client has no valid
+// credentials.
+func isUnauthenticated(err error) bool {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 401 {
+		return true
+	}
+	return errors.Is(err, ErrRefreshTokenExpired) || errors.Is(err, ErrRefreshTokenReuse)
+}
+
+// WhoamiInto is like Whoami but decodes the response into out.
+func (s *AuthService) WhoamiInto(ctx context.Context, out any) error {
+	raw, err := s.Whoami(ctx)
+	return decodeResult(raw, err, out)
+}
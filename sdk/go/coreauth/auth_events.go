@@ -0,0 +1,77 @@
+package coreauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuthEventType identifies a step in the token lifecycle.
+type AuthEventType string
+
+// Auth event types reported to a WithAuthEvents hook.
+const (
+	// AuthEventLogin fires when a login returns an access token.
+	AuthEventLogin AuthEventType = "login"
+	// AuthEventUnauthorized fires when a 401 response triggers an
+	// automatic token refresh.
+	AuthEventUnauthorized AuthEventType = "unauthorized"
+	// AuthEventRefresh fires when a token refresh succeeds.
+	AuthEventRefresh AuthEventType = "refresh"
+	// AuthEventRefreshFailed fires when a token refresh fails.
+	AuthEventRefreshFailed AuthEventType = "refresh_failed"
+	// AuthEventLogout fires when a logout succeeds.
+	AuthEventLogout AuthEventType = "logout"
+)
+
+// AuthEvent describes a token lifecycle event.
+type AuthEvent struct {
+	Type AuthEventType
+	Time time.Time
+	// TokenFingerprint is the first 12 hex digits of the SHA-256 hash of
+	// the access token involved, enough to tell tokens apart in logs
+	// without exposing them. It is empty if no token was involved.
+	TokenFingerprint string
+	// Err is set for AuthEventRefreshFailed.
+	Err error
+}
+
+// WithAuthEvents calls fn for each token lifecycle event: logins, token
+// refreshes (explicit and automatic), refresh failures, 401 responses that
+// trigger a refresh, and logouts. fn is called synchronously and may be
+// called from several goroutines at once.
+func WithAuthEvents(fn func(AuthEvent)) Option {
+	return func(c *Client) {
+		c.http.authEvents = fn
+	}
+}
+
+// emitAuthEvent reports an event to the WithAuthEvents hook, if any.
+func (c *httpClient) emitAuthEvent(typ AuthEventType, token string, err error) {
+	if c.authEvents == nil {
+		return
+	}
+	c.authEvents(AuthEvent{Type: typ, Time: time.Now(), TokenFingerprint: tokenFingerprint(token), Err: err})
+}
+
+// tokenFingerprint returns a short SHA-256 fingerprint of a token. JWTs
+// share their leading characters, so a prefix would not tell them apart.
+func tokenFingerprint(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:6])
+}
+
+// accessTokenFrom extracts the access_token field from an auth response.
+func accessTokenFrom(raw json.RawMessage) string {
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if json.Unmarshal(raw, &body) != nil {
+		return ""
+	}
+	return body.AccessToken
+}
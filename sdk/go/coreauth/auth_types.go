@@ -1,5 +1,10 @@
 package coreauth
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // RegisterRequest represents a user registration request.
 type RegisterRequest struct {
 	TenantID string  `json:"tenant_id"`
@@ -27,15 +32,64 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// Login response statuses reported in AuthResponse.Status.
+const (
+	AuthStatusSuccess               = "success"
+	AuthStatusMfaRequired           = "mfa_required"
+	AuthStatusMfaEnrollmentRequired = "mfa_enrollment_required"
+)
+
 // AuthResponse represents the response from authentication endpoints.
 type AuthResponse struct {
+	// Status is one of the AuthStatus constants; only AuthStatusSuccess
+	// carries tokens.
+	Status       string         `json:"status,omitempty"`
 	AccessToken  string         `json:"access_token"`
 	RefreshToken string         `json:"refresh_token"`
 	TokenType    string         `json:"token_type"`
 	ExpiresIn    int            `json:"expires_in"`
 	User         map[string]any `json:"user,omitempty"`
 	MfaRequired  *bool          `json:"mfa_required,omitempty"`
-	MfaToken     *string        `json:"mfa_token,omitempty"`
+	// MfaToken is the challenge token of an AuthStatusMfaRequired response,
+	// sent by the server as challenge_token.
+	MfaToken *string `json:"mfa_token,omitempty"`
+	// Methods lists the MFA method types the user can complete the
+	// challenge with.
+	Methods []string `json:"methods,omitempty"`
+	Message string   `json:"message,omitempty"`
+	// EnrollmentToken, GracePeriodExpires and CanSkip are set on an
+	// AuthStatusMfaEnrollmentRequired response.
+	EnrollmentToken    *string `json:"enrollment_token,omitempty"`
+	GracePeriodExpires *string `json:"grace_period_expires,omitempty"`
+	CanSkip            *bool   `json:"can_skip,omitempty"`
+}
+
+// UnmarshalJSON decodes the response, filling MfaToken from challenge_token
+// and MfaRequired from the status.
+func (r *AuthResponse) UnmarshalJSON(data []byte) error {
+	type plain AuthResponse
+	var aux struct {
+		plain
+		ChallengeToken *string `json:"challenge_token"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*r = AuthResponse(aux.plain)
+	if r.MfaToken == nil {
+		r.MfaToken = aux.ChallengeToken
+	}
+	if r.MfaRequired == nil && r.Status == AuthStatusMfaRequired {
+		required := true
+		r.MfaRequired = &required
+	}
+	return nil
+}
+
+// GracePeriodExpiresTime returns the parsed end of the MFA enrollment grace
+// period, or the zero time if unknown.
+func (r AuthResponse) GracePeriodExpiresTime() time.Time {
+	return parseTimestamp(r.GracePeriodExpires)
 }
 
 // UserProfile represents a user's profile information.
@@ -52,6 +106,33 @@ type UserProfile struct {
 	UpdatedAt     *string        `json:"updated_at,omitempty"`
 }
 
+// WhoamiSession represents the current session returned by Whoami.
+type WhoamiSession struct {
+	ID                    string                 `json:"id"`
+	Identity              map[string]any         `json:"identity,omitempty"`
+	AuthenticatedAt       *string                `json:"authenticated_at,omitempty"`
+	ExpiresAt             *string                `json:"expires_at,omitempty"`
+	AuthenticationMethods []AuthenticationMethod `json:"authentication_methods,omitempty"`
+}
+
+// ExpiresAtTime returns the parsed expiry time, or the zero time if unknown.
+func (s WhoamiSession) ExpiresAtTime() time.Time {
+	return parseTimestamp(s.ExpiresAt)
+}
+
+// AuthenticationMethod is a method completed to establish a session.
+type AuthenticationMethod struct {
+	Method      string  `json:"method"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// MeResult combines the profile and session of the authenticated user, as
+// returned by AuthService.Me.
+type MeResult struct {
+	Profile UserProfile
+	Session WhoamiSession
+}
+
 // UpdateProfileRequest represents a request to update a user's profile.
 type UpdateProfileRequest struct {
 	FirstName *string `json:"first_name,omitempty"`
@@ -63,6 +144,26 @@ type UpdateProfileRequest struct {
 	Timezone  *string `json:"timezone,omitempty"`
 }
 
+// VerifyEmailResult describes the outcome of verifying an email address.
+type VerifyEmailResult struct {
+	Verified bool
+	Message  string
+	// Session holds the tokens issued if the server logs the user in on
+	// verification, or nil otherwise.
+	Session *AuthResponse
+}
+
+// ResendResult describes the outcome of resending a verification email.
+type ResendResult struct {
+	Message string
+	// RetryAfter is how long to wait before another resend is allowed, or
+	// zero if the server did not say.
+	RetryAfter time.Duration
+	// NextAllowedAt is when another resend is allowed, or the zero time if
+	// unknown.
+	NextAllowedAt time.Time
+}
+
 // ChangePasswordRequest represents a request to change a user's password.
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password"`
@@ -0,0 +1,102 @@
+package coreauth
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Defaults for the zero fields of a BackoffSchedule.
+const (
+	defaultBackoffMax        = time.Minute
+	defaultBackoffMultiplier = 2
+)
+
+// retryJitter is the jitter of the schedule WithRetry uses.
+const retryJitter = 0.5
+
+// BackoffSchedule computes the wait before each retry: Base after the first
+// attempt, multiplied by Multiplier after each further one, capped at Max,
+// and then shortened by a random fraction of up to Jitter. With Jitter 0.5,
+// for example, each wait falls between half and all of the computed value.
+//
+// The schedule WithRetry uses is BackoffSchedule{Base: baseDelay,
+// Jitter: 0.5}. Set Rand to a seeded source to make the jitter
+// reproducible, e.g. to preview a schedule or in tests.
+type BackoffSchedule struct {
+	Base time.Duration
+	// Max caps each wait; 0 means one minute.
+	Max time.Duration
+	// Multiplier is the growth factor between waits; 0 means 2.
+	Multiplier float64
+	// Jitter is the largest fraction, between 0 and 1, by which a wait is
+	// randomly shortened. 0 disables jitter.
+	Jitter float64
+	// Rand is the source of jitter; nil uses the math/rand global source.
+	// A *rand.Rand is not safe for concurrent use, so do not share one
+	// schedule between goroutines that call Next directly.
+	Rand *rand.Rand
+}
+
+// Next returns the wait after the given attempt, counting from 1.
+func (s BackoffSchedule) Next(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	limit := s.Max
+	if limit <= 0 {
+		limit = defaultBackoffMax
+	}
+	mult := s.Multiplier
+	if mult <= 0 {
+		mult = defaultBackoffMultiplier
+	}
+	d := float64(s.Base) * math.Pow(mult, float64(attempt-1))
+	if d > float64(limit) || math.IsNaN(d) {
+		d = float64(limit)
+	}
+	if d <= 0 {
+		return 0
+	}
+	if jitter := math.Min(s.Jitter, 1); jitter > 0 {
+		r := rand.Float64
+		if s.Rand != nil {
+			r = s.Rand.Float64
+		}
+		d -= d * jitter * r()
+	}
+	return time.Duration(d)
+}
+
+// Schedule returns the waits after each of the first n attempts, i.e. the
+// waits of a call retried up to n+1 times in total. With a seeded Rand the
+// result is reproducible; with jitter from the global source each call
+// returns a different sample.
+func (s BackoffSchedule) Schedule(n int) []time.Duration {
+	waits := make([]time.Duration, n)
+	for i := range waits {
+		waits[i] = s.Next(i + 1)
+	}
+	return waits
+}
+
+// WithRetrySchedule makes WithRetry wait according to s instead of its
+// default doubling schedule. It does not enable retrying by itself;
+// WithRetry still sets the number of attempts, and its baseDelay is
+// ignored. The client serializes calls to s.Next, so a seeded s.Rand may be
+// used even though the client makes concurrent calls.
+func WithRetrySchedule(s BackoffSchedule) Option {
+	return func(c *Client) {
+		c.http.retrySchedule = &s
+	}
+}
+
+// retryWait returns the wait after the given attempt under WithRetry.
+func (c *httpClient) retryWait(attempt int) time.Duration {
+	if c.retrySchedule == nil {
+		return BackoffSchedule{Base: c.retryDelay, Jitter: retryJitter}.Next(attempt)
+	}
+	c.retryScheduleMu.Lock()
+	defer c.retryScheduleMu.Unlock()
+	return c.retrySchedule.Next(attempt)
+}
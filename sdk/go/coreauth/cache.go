@@ -0,0 +1,127 @@
+package coreauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache holds GET responses that are revalidated with ETags.
+type responseCache struct {
+	mu         sync.Mutex
+	entries    map[string]cachedResponse
+	defaultTTL time.Duration
+}
+
+type cachedResponse struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// WithMetadataCacheTTL sets how long the OAuth2 discovery document and JWKS
+// are reused without contacting the server when the response carries no
+// Cache-Control max-age. The default is zero: the cached copy is always
+// revalidated, which costs a request but only transfers the body again if
+// its ETag changed.
+func WithMetadataCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		c.http.cache.defaultTTL = d
+	}
+}
+
+// getCached performs a GET whose response is cached. A fresh cached copy is
+// returned without a request; a stale one is revalidated with If-None-Match
+// and reused on 304 Not Modified. Freshness comes from the response's
+// Cache-Control max-age, or the cache's default TTL if absent.
+func (c *httpClient) getCached(ctx context.Context, path string) (json.RawMessage, error) {
+	return c.fetchCached(ctx, path, false)
+}
+
+// revalidateCached is like getCached but contacts the server even if the
+// cached copy is fresh.
+func (c *httpClient) revalidateCached(ctx context.Context, path string) (json.RawMessage, error) {
+	return c.fetchCached(ctx, path, true)
+}
+
+func (c *httpClient) fetchCached(ctx context.Context, path string, revalidate bool) (json.RawMessage, error) {
+	cache := &c.cache
+	cache.mu.Lock()
+	entry, ok := cache.entries[path]
+	cache.mu.Unlock()
+	if ok && !revalidate && time.Now().Before(entry.expires) {
+		return bytes.Clone(entry.body), nil
+	}
+	if ok && entry.etag != "" {
+		ctx = withRequestHeader(ctx, "If-None-Match", entry.etag)
+	}
+
+	resp, err := c.execute(ctx, http.MethodGet, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	decoded, err := decodedBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer decoded.Close()
+	body, err := io.ReadAll(decoded)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to read response: %v", err), Err: err}
+	}
+
+	maxAge, cacheable := cacheLifetime(resp.Header.Get("Cache-Control"), cache.defaultTTL)
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		body = entry.body
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		if len(body) == 0 {
+			return nil, nil
+		}
+		entry = cachedResponse{body: body, etag: resp.Header.Get("ETag")}
+	default:
+		return nil, responseError(resp, body)
+	}
+
+	cache.mu.Lock()
+	if cacheable {
+		if cache.entries == nil {
+			cache.entries = make(map[string]cachedResponse)
+		}
+		entry.expires = time.Now().Add(maxAge)
+		cache.entries[path] = entry
+	} else {
+		delete(cache.entries, path)
+	}
+	cache.mu.Unlock()
+	return bytes.Clone(body), nil
+}
+
+// cacheLifetime reads a Cache-Control header. It returns how long a response
+// stays fresh (the max-age, or def if none is given; zero for no-cache) and
+// whether it may be stored at all.
+func cacheLifetime(header string, def time.Duration) (time.Duration, bool) {
+	maxAge := def
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			return 0, false
+		case "no-cache":
+			maxAge = 0
+		case "max-age":
+			if secs, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && secs >= 0 {
+				maxAge = time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return maxAge, true
+}
@@ -1,7 +1,10 @@
 package coreauth
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,17 @@ func WithToken(token string) Option {
 	}
 }
 
+// WithRefreshToken enables automatic token refresh. When a request fails with
+// a 401 reporting an expired or invalid access token, the client exchanges
+// the refresh token for a new access token and retries the request once.
+// Credential failures, such as a wrong password, are returned as is.
+// Concurrent requests share a single refresh.
+func WithRefreshToken(refreshToken string) Option {
+	return func(c *Client) {
+		c.http.setRefreshToken(refreshToken)
+	}
+}
+
 // WithHTTPClient sets a custom http.Client.
 func WithHTTPClient(hc *http.Client) Option {
 	return func(c *Client) {
@@ -22,7 +36,69 @@ func WithHTTPClient(hc *http.Client) Option {
 	}
 }
 
-// Client is the main CoreAuth SDK client.
+// WithUserAgent replaces the default User-Agent header, coreauth-go/<Version>,
+// sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.http.userAgent = userAgent
+	}
+}
+
+// WithDryRun makes the client hand every request to sink instead of sending
+// it. Each call then returns an empty successful response, which lets tests
+// assert the exact requests a flow would make without a server.
+func WithDryRun(sink func(method, url string, body []byte)) Option {
+	return func(c *Client) {
+		c.http.dryRun = sink
+	}
+}
+
+// WithStripNulls removes nil entries from map[string]any request bodies,
+// including nested maps, before they are sent, so a dynamically built map
+// cannot accidentally null out a server-side value. Typed request structs
+// are sent unchanged.
+func WithStripNulls() Option {
+	return func(c *Client) {
+		c.http.stripNulls = true
+	}
+}
+
+// WithModelValidation makes FgaService.WriteModel and FgaService.Bootstrap
+// check the model with ValidateModelSchema before sending it, returning a
+// *ModelValidationError instead of writing a model with issues.
+func WithModelValidation() Option {
+	return func(c *Client) {
+		c.http.validateModels = true
+	}
+}
+
+// WithAutoStoreToken makes typed auth calls that receive a session, such as
+// AuthService.LoginTyped, AuthService.PasswordlessVerifyTyped,
+// OAuth2Service.ExchangeCode, OAuth2Service.ClientCredentials and
+// ApplicationsService.AuthenticateTyped, store the returned access token on
+// the client as if SetToken had been called. It is off by default so callers
+// juggling several identities are not surprised by token changes.
+func WithAutoStoreToken() Option {
+	return func(c *Client) {
+		c.http.autoStoreToken = true
+	}
+}
+
+// WithSSORedirectURI sets the URI an SSO provider returns the user to after
+// login. With it, a password login refused because the organization
+// enforces SSO returns a *SSORequiredError whose RedirectURL starts a login
+// with the first of the user's SSO providers.
+func WithSSORedirectURI(uri string) Option {
+	return func(c *Client) {
+		c.http.ssoRedirectURI = uri
+	}
+}
+
+//go:generate go run ../internal/genapi
+
+// Client is the main CoreAuth SDK client. Each service also has an
+// interface, such as AuthAPI for *AuthService, that code under test can
+// depend on in place of the concrete service.
 type Client struct {
 	http         *httpClient
 	Auth         *AuthService
@@ -61,12 +137,59 @@ func NewClient(baseURL string, opts ...Option) *Client {
 	return c
 }
 
-// SetToken updates the bearer token used for all requests.
+// SetToken updates the bearer token used for all requests. It also clears a
+// previous ErrRefreshTokenExpired, so requests stop failing fast with it.
+// The rejected refresh token was discarded, so automatic refresh resumes
+// only once a new one is supplied with SetRefreshToken.
 func (c *Client) SetToken(token string) {
 	c.http.setToken(token)
 }
 
-// ClearToken removes the bearer token.
+// SetRefreshToken updates the refresh token used for automatic refresh and
+// clears a previous ErrRefreshTokenExpired.
+func (c *Client) SetRefreshToken(refreshToken string) {
+	c.http.setRefreshToken(refreshToken)
+}
+
+// Refresh exchanges the client's refresh token for a new access token now,
+// storing the new token and, if the server rotated it, the new refresh
+// token. It shares the single in-flight refresh used by automatic refresh.
+// A rejected refresh token fails with ErrRefreshTokenExpired, or with
+// ErrRefreshTokenReuse if the server reported reuse of a rotated token;
+// either way the caller must log in again.
+func (c *Client) Refresh(ctx context.Context) (string, error) {
+	token, err := c.http.refreshAccessToken(ctx, c.http.currentToken())
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", &CoreAuthError{Message: "no refresh token set"}
+	}
+	return token, nil
+}
+
+// Response is a raw API response returned by Client.Do.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Body is nil for an empty or 204 response.
+	Body json.RawMessage
+}
+
+// Do sends a request through the client's full pipeline (token, refresh,
+// middlewares, retries) and returns the raw response, including headers
+// such as X-RateLimit-Remaining or Location that the service methods do not
+// expose. body, if not nil, is sent as JSON. A non-2xx response returns the
+// same error a service method would, together with the Response.
+func (c *Client) Do(ctx context.Context, method, path string, body any) (*Response, error) {
+	r, err := c.http.encodeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.http.do(ctx, strings.ToUpper(method), path, r, "application/json")
+}
+
+// ClearToken removes the bearer token and any refresh token.
 func (c *Client) ClearToken() {
 	c.http.clearToken()
 }
@@ -16,21 +16,59 @@ func (s *ConnectionsService) List(ctx context.Context, orgID string) (json.RawMe
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/connections", orgID), nil)
 }
 
+// ListInto is like List but decodes the response into out.
+func (s *ConnectionsService) ListInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.List(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // Create creates an organization-scoped connection.
 func (s *ConnectionsService) Create(ctx context.Context, orgID string, req CreateConnectionRequest) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/connections", orgID), req)
 }
 
+// CreateInto is like Create but decodes the response into out.
+func (s *ConnectionsService) CreateInto(ctx context.Context, orgID string, req CreateConnectionRequest, out any) error {
+	raw, err := s.Create(ctx, orgID, req)
+	return decodeResult(raw, err, out)
+}
+
+// CreateTyped creates an organization-scoped connection and returns it. The
+// connection type is checked locally first; unknown types return an error
+// matching ErrUnsupportedConnectionType.
+func (s *ConnectionsService) CreateTyped(ctx context.Context, orgID string, req CreateConnectionRequest) (*Connection, error) {
+	if err := validateConnectionType(req.ConnectionType); err != nil {
+		return nil, err
+	}
+	var conn Connection
+	if err := s.CreateInto(ctx, orgID, req, &conn); err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
+
 // Get retrieves a specific connection.
 func (s *ConnectionsService) Get(ctx context.Context, orgID, connectionID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/connections/%s", orgID, connectionID), nil)
 }
 
+// GetInto is like Get but decodes the response into out.
+func (s *ConnectionsService) GetInto(ctx context.Context, orgID, connectionID string, out any) error {
+	raw, err := s.Get(ctx, orgID, connectionID)
+	return decodeResult(raw, err, out)
+}
+
 // Update updates a connection.
 func (s *ConnectionsService) Update(ctx context.Context, orgID, connectionID string, req UpdateConnectionRequest) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/connections/%s", orgID, connectionID), req)
 }
 
+// UpdateInto is like Update but decodes the response into out.
+func (s *ConnectionsService) UpdateInto(ctx context.Context, orgID, connectionID string, req UpdateConnectionRequest, out any) error {
+	raw, err := s.Update(ctx, orgID, connectionID, req)
+	return decodeResult(raw, err, out)
+}
+
 // Delete deletes a connection.
 func (s *ConnectionsService) Delete(ctx context.Context, orgID, connectionID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/connections/%s", orgID, connectionID), nil)
@@ -42,12 +80,44 @@ func (s *ConnectionsService) GetAuthMethods(ctx context.Context, orgID string) (
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/connections/auth-methods", orgID), nil)
 }
 
+// GetAuthMethodsInto is like GetAuthMethods but decodes the response into out.
+func (s *ConnectionsService) GetAuthMethodsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetAuthMethods(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // ListAll returns all connections (admin).
 func (s *ConnectionsService) ListAll(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/admin/connections", nil)
 }
 
+// ListAllInto is like ListAll but decodes the response into out.
+func (s *ConnectionsService) ListAllInto(ctx context.Context, out any) error {
+	raw, err := s.ListAll(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // CreatePlatform creates a platform-scoped connection (admin).
 func (s *ConnectionsService) CreatePlatform(ctx context.Context, req CreateConnectionRequest) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/admin/connections", req)
 }
+
+// CreatePlatformInto is like CreatePlatform but decodes the response into out.
+func (s *ConnectionsService) CreatePlatformInto(ctx context.Context, req CreateConnectionRequest, out any) error {
+	raw, err := s.CreatePlatform(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
+// CreatePlatformTyped creates a platform-scoped connection (admin) and
+// returns it. Like CreateTyped, unknown connection types are rejected
+// locally with an error matching ErrUnsupportedConnectionType.
+func (s *ConnectionsService) CreatePlatformTyped(ctx context.Context, req CreateConnectionRequest) (*Connection, error) {
+	if err := validateConnectionType(req.ConnectionType); err != nil {
+		return nil, err
+	}
+	var conn Connection
+	if err := s.CreatePlatformInto(ctx, req, &conn); err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
@@ -1,5 +1,29 @@
 package coreauth
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Known connection types.
+const (
+	ConnectionTypeDatabase = "database"
+	ConnectionTypeOIDC     = "oidc"
+	ConnectionTypeSAML     = "saml"
+	ConnectionTypeOAuth2   = "oauth2"
+	ConnectionTypeSocial   = "social"
+)
+
+// validateConnectionType returns an error matching
+// ErrUnsupportedConnectionType unless t is one of the known connection types.
+func validateConnectionType(t string) error {
+	switch t {
+	case ConnectionTypeDatabase, ConnectionTypeOIDC, ConnectionTypeSAML, ConnectionTypeOAuth2, ConnectionTypeSocial:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrUnsupportedConnectionType, t)
+}
+
 // Connection represents an authentication connection (database, OIDC, SAML, OAuth2, social).
 type Connection struct {
 	ID             string         `json:"id"`
@@ -34,3 +58,93 @@ type AuthMethod struct {
 	MethodType   string `json:"method_type"`
 	Scope        string `json:"scope"`
 }
+
+// OidcConnectionConfig represents the configuration of an OIDC connection.
+type OidcConnectionConfig struct {
+	Issuer                string         `json:"issuer"`
+	ClientID              string         `json:"client_id"`
+	ClientSecret          string         `json:"client_secret,omitempty"`
+	AuthorizationEndpoint string         `json:"authorization_endpoint"`
+	TokenEndpoint         string         `json:"token_endpoint"`
+	UserinfoEndpoint      *string        `json:"userinfo_endpoint,omitempty"`
+	JwksURI               string         `json:"jwks_uri"`
+	Scopes                []string       `json:"scopes,omitempty"`
+	ClaimMappings         *ClaimMappings `json:"claim_mappings,omitempty"`
+	GroupsClaim           *string        `json:"groups_claim,omitempty"`
+	GroupRoleMappings     map[string]any `json:"group_role_mappings,omitempty"`
+}
+
+// ClaimMappings maps identity provider claims to user profile fields.
+type ClaimMappings struct {
+	Email     string  `json:"email"`
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+}
+
+// SamlConnectionConfig represents the configuration of a SAML connection.
+type SamlConnectionConfig struct {
+	SsoURL             string `json:"sso_url"`
+	EntityID           string `json:"entity_id"`
+	Certificate        string `json:"certificate"`
+	SignRequests       bool   `json:"sign_requests"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+}
+
+// DatabaseConnectionConfig represents the configuration of a database (username/password) connection.
+type DatabaseConnectionConfig struct {
+	PasswordPolicy *PasswordPolicy `json:"password_policy,omitempty"`
+}
+
+// PasswordPolicy represents the password rules enforced by a database connection.
+type PasswordPolicy struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireNumber    bool `json:"require_number"`
+	RequireSpecial   bool `json:"require_special"`
+}
+
+// AsOidcConfig decodes the connection's config into an OidcConnectionConfig.
+// It returns an error if the connection is not an OIDC connection.
+func (c *Connection) AsOidcConfig() (*OidcConnectionConfig, error) {
+	var cfg OidcConnectionConfig
+	if err := c.decodeConfig(ConnectionTypeOIDC, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// AsSamlConfig decodes the connection's config into a SamlConnectionConfig.
+// It returns an error if the connection is not a SAML connection.
+func (c *Connection) AsSamlConfig() (*SamlConnectionConfig, error) {
+	var cfg SamlConnectionConfig
+	if err := c.decodeConfig(ConnectionTypeSAML, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// AsDatabaseConfig decodes the connection's config into a DatabaseConnectionConfig.
+// It returns an error if the connection is not a database connection.
+func (c *Connection) AsDatabaseConfig() (*DatabaseConnectionConfig, error) {
+	var cfg DatabaseConnectionConfig
+	if err := c.decodeConfig(ConnectionTypeDatabase, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Connection) decodeConfig(connectionType string, out any) error {
+	if c.ConnectionType != connectionType {
+		return &CoreAuthError{Message: fmt.Sprintf("connection %s is of type %q, not %q", c.ID, c.ConnectionType, connectionType)}
+	}
+	b, err := json.Marshal(c.Config)
+	if err != nil {
+		return &CoreAuthError{Message: fmt.Sprintf("failed to encode connection config: %v", err)}
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return &CoreAuthError{Message: fmt.Sprintf("failed to decode %s connection config: %v", connectionType, err)}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package coreauth
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type contextKey int
+
+const (
+	headersContextKey contextKey = iota
+	attemptContextKey
+	refreshContextKey
+	streamContextKey
+	conflictRetryContextKey
+)
+
+// withConflictRetry marks ctx as belonging to an operation whose 409
+// responses are transient and may be retried under WithConflictRetry.
+func withConflictRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, conflictRetryContextKey, true)
+}
+
+func isConflictRetryable(ctx context.Context) bool {
+	v, _ := ctx.Value(conflictRetryContextKey).(bool)
+	return v
+}
+
+// withStreamRequest marks ctx as belonging to a long-lived streaming request.
+func withStreamRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamContextKey, true)
+}
+
+func isStreamRequest(ctx context.Context) bool {
+	v, _ := ctx.Value(streamContextKey).(bool)
+	return v
+}
+
+// withRefreshRequest marks ctx as belonging to a token refresh, which must
+// not itself trigger a refresh.
+func withRefreshRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshContextKey, true)
+}
+
+func isRefreshRequest(ctx context.Context) bool {
+	v, _ := ctx.Value(refreshContextKey).(bool)
+	return v
+}
+
+// attemptMeta records which attempt of a call a request belongs to.
+type attemptMeta struct {
+	number int
+	start  time.Time
+}
+
+// withAttempt returns a copy of ctx marking the request as the given attempt
+// of a call that started at start.
+func withAttempt(ctx context.Context, number int, start time.Time) context.Context {
+	return context.WithValue(ctx, attemptContextKey, attemptMeta{number: number, start: start})
+}
+
+// AttemptInfo reports which attempt a request is, starting from 1, and the
+// time elapsed since the first attempt of the call began. It is meant for
+// middlewares, which receive the request's context via req.Context(); ok is
+// false for contexts not created by the client.
+func AttemptInfo(ctx context.Context) (attempt int, elapsed time.Duration, ok bool) {
+	a, ok := ctx.Value(attemptContextKey).(attemptMeta)
+	if !ok {
+		return 0, 0, false
+	}
+	return a.number, time.Since(a.start), true
+}
+
+// withRequestHeader returns a copy of ctx that adds a header to every request
+// made with it.
+func withRequestHeader(ctx context.Context, key, value string) context.Context {
+	h := requestHeaders(ctx).Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	h.Set(key, value)
+	return context.WithValue(ctx, headersContextKey, h)
+}
+
+// requestHeaders returns the per-call headers stored in ctx, if any.
+func requestHeaders(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headersContextKey).(http.Header)
+	return h
+}
+
+// WithIfMatch returns a copy of ctx that sends an If-Match header with the
+// given ETag. Against a server or proxy that honors it, updates made with it
+// fail with ErrPreconditionFailed if the resource changed since the ETag was
+// read, preventing lost updates. The CoreAuth server currently ignores the
+// header.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return withRequestHeader(ctx, "If-Match", etag)
+}
+
+// WithIdempotencyKey returns a copy of ctx that sends the given
+// Idempotency-Key instead of a generated one, so a call repeated by the
+// caller, e.g. after a crash, can be correlated with the original. The
+// CoreAuth server currently ignores the header, so a repeated call takes
+// effect again.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return withRequestHeader(ctx, IdempotencyKeyHeader, key)
+}
+
+// Headers set by WithActorAttribution.
+const (
+	ActorIDHeader   = "X-Actor-ID"
+	ActorNameHeader = "X-Actor-Name"
+	ActorTypeHeader = "X-Actor-Type"
+)
+
+// ActorInfo identifies who an action is performed on behalf of. Empty fields
+// are not sent.
+type ActorInfo struct {
+	ID   string
+	Name string
+	// Type is the kind of actor, such as "user".
+	Type string
+}
+
+// WithActorAttribution returns a copy of ctx that sends actor as the
+// X-Actor-ID, X-Actor-Name and X-Actor-Type headers, for a tool acting for a
+// person with a service token. The CoreAuth server currently ignores these
+// headers: audit entries name the token's subject, not the actor, until the
+// server supports them.
+func WithActorAttribution(ctx context.Context, actor ActorInfo) context.Context {
+	for header, value := range map[string]string{
+		ActorIDHeader:   actor.ID,
+		ActorNameHeader: actor.Name,
+		ActorTypeHeader: actor.Type,
+	} {
+		if value != "" {
+			ctx = withRequestHeader(ctx, header, value)
+		}
+	}
+	return ctx
+}
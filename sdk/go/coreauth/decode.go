@@ -0,0 +1,82 @@
+package coreauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// decodeJSON unmarshals a response body into out. Empty and null bodies
+// (e.g. from 204 responses) leave out untouched.
+func decodeJSON(raw json.RawMessage, out any) error {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(trimmed, out); err != nil {
+		return &CoreAuthError{Message: fmt.Sprintf("failed to decode response: %v", err)}
+	}
+	return nil
+}
+
+// parseTimestamp parses an RFC 3339 timestamp returned by the API. It returns
+// the zero time if s is nil, empty, or not a valid timestamp.
+func parseTimestamp(s *string) time.Time {
+	if s == nil || *s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// timeRangeParams returns query parameters bounding a time range, with each
+// bound formatted in UTC using format (time.RFC3339 if empty). Zero bounds,
+// and bounds whose key is empty, are omitted.
+func timeRangeParams(from, to time.Time, fromKey, toKey, format string) map[string]string {
+	if format == "" {
+		format = time.RFC3339
+	}
+	params := map[string]string{}
+	if !from.IsZero() && fromKey != "" {
+		params[fromKey] = from.UTC().Format(format)
+	}
+	if !to.IsZero() && toKey != "" {
+		params[toKey] = to.UTC().Format(format)
+	}
+	return params
+}
+
+// redactSecret hides a secret value for display, keeping only whether it is set.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// decodeResult forwards err if set and otherwise decodes raw into out.
+func decodeResult(raw json.RawMessage, err error, out any) error {
+	if err != nil {
+		return err
+	}
+	return decodeJSON(raw, out)
+}
+
+// Decode forwards err if set and otherwise decodes raw into a T, so a raw
+// service call can be typed in one line:
+//
+//	profile, err := coreauth.Decode[coreauth.UserProfile](c.Auth.GetProfile(ctx))
+//
+// An empty or null body, such as that of a 204 response, yields the zero T.
+func Decode[T any](raw json.RawMessage, err error) (T, error) {
+	var out T
+	if err := decodeResult(raw, err, &out); err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
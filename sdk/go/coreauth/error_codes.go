@@ -0,0 +1,59 @@
+package coreauth
+
+import "errors"
+
+// Error codes reported by the server in ApiError.ErrorCode. Switch on these
+// instead of string literals; HasCode checks an error for one.
+const (
+	// Authentication.
+	CodeLoginFailed        = "login_failed"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeSSORequired        = "sso_required"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeInvalidToken       = "invalid_token"
+	CodeTokenExpired       = "token_expired"
+	CodeInvalidCode        = "invalid_code"
+	CodeOTPExpired         = "otp_expired"
+	CodeRateLimited        = "rate_limited"
+	CodeInvalidTenant      = "invalid_tenant"
+
+	// Passwords.
+	CodeInvalidPassword = "invalid_password"
+	CodeWeakPassword    = "weak_password"
+	CodeNoPassword      = "no_password"
+
+	// Lookups and conflicts.
+	CodeNotFound        = "not_found"
+	CodeUserNotFound    = "user_not_found"
+	CodeTenantNotFound  = "tenant_not_found"
+	CodeSessionNotFound = "session_not_found"
+	CodeStoreNotFound   = "store_not_found"
+	CodeModelNotFound   = "model_not_found"
+	CodeAlreadyExists   = "already_exists"
+
+	// Malformed requests.
+	CodeBadRequest      = "bad_request"
+	CodeInvalidRequest  = "invalid_request"
+	CodeInvalidInput    = "invalid_input"
+	CodeValidationError = "validation_error"
+
+	// OAuth2 endpoints.
+	CodeInvalidClient = "invalid_client"
+	CodeInvalidGrant  = "invalid_grant"
+	CodeInvalidState  = "invalid_state"
+	CodeAccessDenied  = "access_denied"
+	CodeExpiredToken  = "expired_token"
+
+	// Server-side failures.
+	CodeInternalError = "internal_error"
+	CodeDatabaseError = "database_error"
+	CodeMaintenance   = "maintenance"
+)
+
+// HasCode reports whether err, or any error it wraps, is an *ApiError with
+// the given error code.
+func HasCode(err error, code string) bool {
+	var apiErr *ApiError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == code
+}
@@ -1,27 +1,327 @@
 package coreauth
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrPreconditionFailed is matched by a 412 ApiError, returned when an update
+// sent with WithIfMatch targets a resource that has since changed.
+var ErrPreconditionFailed = errors.New("precondition failed: resource was modified")
+
+// ErrResendCooldown is matched by a *CooldownError, returned when a
+// verification email was resent too recently.
+var ErrResendCooldown = errors.New("resend is cooling down")
+
+// ErrVerificationTokenExpired is returned by AuthService.VerifyEmailTyped when
+// the verification link has expired; offer to resend it.
+var ErrVerificationTokenExpired = errors.New("verification token expired")
+
+// ErrVerificationTokenInvalid is returned by AuthService.VerifyEmailTyped when
+// the verification token is unknown or has already been used.
+var ErrVerificationTokenInvalid = errors.New("verification token is invalid or already used")
+
+// ErrRefreshTokenExpired is returned when automatic token refresh fails
+// because the refresh token was rejected. Every request waiting on that
+// refresh fails with it, and later requests fail fast without retrying the
+// refresh until a new token is supplied with SetToken or SetRefreshToken.
+var ErrRefreshTokenExpired = errors.New("refresh token expired")
+
+// ErrRefreshTokenReuse is matched by an ApiError whose error code reports
+// that an already-rotated refresh token was presented again. Unlike
+// ErrRefreshTokenExpired it suggests the token may have been stolen: the
+// server revokes the whole token family, so the caller must force a full
+// re-login and may want to raise an alert. Automatic refresh treats it as
+// terminal in the same way as ErrRefreshTokenExpired.
+//
+// The CoreAuth server does not detect reuse: it rejects any bad refresh
+// token with refresh_failed, which surfaces as ErrRefreshTokenExpired. The
+// codes matched here are ones used by other token services and gateways.
+var ErrRefreshTokenReuse = errors.New("refresh token reuse detected")
+
+// refreshTokenReuseCodes are error codes that token services other than
+// CoreAuth use to report a reused refresh token.
+var refreshTokenReuseCodes = map[string]bool{
+	"refresh_token_reused":         true,
+	"refresh_token_reuse_detected": true,
+	"token_reuse_detected":         true,
+}
+
+// ErrStateExpired is returned by VerifySignedState when the state's TTL has
+// passed.
+var ErrStateExpired = errors.New("oauth state expired")
+
+// ErrStateInvalid is returned by VerifySignedState when the state is
+// malformed or its signature does not match.
+var ErrStateInvalid = errors.New("oauth state is invalid or has been tampered with")
+
+// ErrInvalidGrant is matched by an ApiError with the OAuth2 error code
+// invalid_grant, returned by the token endpoint when the code or refresh
+// token in a grant is wrong or no longer valid.
+var ErrInvalidGrant = errors.New("invalid grant")
+
+// ErrAccessDenied is matched by an ApiError with the OAuth2 error code
+// access_denied, e.g. when the user declines an authorization request.
+var ErrAccessDenied = errors.New("access denied")
+
+// ErrWebhookSignatureMismatch is returned by VerifyWebhookSignature when the
+// signature does not match the payload under any of the given secrets.
+var ErrWebhookSignatureMismatch = errors.New("webhook signature does not match")
+
+// ErrWebhookTimestampStale is returned by VerifyWebhookSignature when a
+// correctly signed webhook is older (or further in the future) than the
+// replay tolerance allows.
+var ErrWebhookTimestampStale = errors.New("webhook timestamp is outside the tolerance window")
+
+// ErrCurrentPasswordWrong is returned by AuthService.ChangePasswordChecked
+// when the server rejects the current password.
+var ErrCurrentPasswordWrong = errors.New("current password is incorrect")
+
+// ErrMaintenance is matched by a *MaintenanceError, returned while the
+// server is down for planned maintenance.
+var ErrMaintenance = errors.New("service is in maintenance mode")
+
+// ErrUnsupportedConnectionType is returned by ConnectionsService.CreateTyped
+// and CreatePlatformTyped when the connection type is not one of the
+// ConnectionType constants; the request is not sent.
+var ErrUnsupportedConnectionType = errors.New("unsupported connection type")
+
+// ErrSSORequired is matched by a *SSORequiredError, returned when password
+// login is refused because the user's organization enforces SSO.
+var ErrSSORequired = errors.New("organization requires SSO login")
+
+// ErrNotAuthenticated is returned by AuthService.Me when the client has no
+// valid session. It wraps the server's 401 *ApiError.
+var ErrNotAuthenticated = errors.New("not authenticated")
+
+// ErrSessionNotFound is matched by the ApiError returned when revoking a
+// session that does not exist, for example because it already expired.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionStillActive is returned by ScimService.RevokeSessionChecked when
+// the session is still listed after it was revoked.
+var ErrSessionStillActive = errors.New("session is still active after revocation")
+
+// ErrInvalidToken is returned by OAuth2Service.VerifyJWT when a token is
+// malformed, its signature does not verify, or its claims do not match the
+// VerifyOptions.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrTokenExpired is returned by OAuth2Service.VerifyJWT when a token's exp
+// claim has passed.
+var ErrTokenExpired = errors.New("token expired")
+
+// ErrInvalidSignature is matched by the error OAuth2Service.VerifyIDToken
+// returns when a token's signature does not verify, including when no
+// signing key with the token's kid exists. The error also matches
+// ErrInvalidToken.
+var ErrInvalidSignature = errors.New("token signature is invalid")
+
+// ErrInvalidClaims is matched by a *ClaimError.
+var ErrInvalidClaims = errors.New("token claims are invalid")
+
+// ErrKeyNotFound is returned by JWKSCache.GetKey when the server's key set
+// has no key with the requested ID.
+var ErrKeyNotFound = errors.New("no JWKS key with that kid")
+
+// ErrMissingClaim is matched by a *MissingClaimError.
+var ErrMissingClaim = errors.New("missing required claim")
 
 // CoreAuthError is the base error type for SDK errors.
 type CoreAuthError struct {
 	Message string
+	// Err is the underlying cause, if any.
+	Err error
 }
 
 func (e *CoreAuthError) Error() string {
 	return e.Message
 }
 
+func (e *CoreAuthError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when input fails local validation before a
+// request is sent.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// CooldownError is returned when an action is rate limited and may be
+// retried after RetryAfter. It matches ErrResendCooldown with errors.Is.
+type CooldownError struct {
+	RetryAfter time.Duration
+	Err        *ApiError
+}
+
+func (e *CooldownError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v: retry after %s", ErrResendCooldown, e.RetryAfter)
+	}
+	return ErrResendCooldown.Error()
+}
+
+func (e *CooldownError) Unwrap() error {
+	return ErrResendCooldown
+}
+
+// MaintenanceError is returned when the server answers 503 because it is in
+// maintenance mode. RetryAfter is the advertised wait, or zero if the server
+// gave none. Under WithRetry, idempotent calls are retried after waiting at
+// least RetryAfter, as long as the context's deadline allows. It matches
+// ErrMaintenance with errors.Is, and errors.As still finds the underlying
+// *ApiError.
+type MaintenanceError struct {
+	RetryAfter time.Duration
+	Err        *ApiError
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v: retry after %s", ErrMaintenance, e.RetryAfter)
+	}
+	return ErrMaintenance.Error()
+}
+
+func (e *MaintenanceError) Unwrap() []error {
+	return []error{ErrMaintenance, e.Err}
+}
+
+// SSORequiredError is returned by password login when the user's
+// organization enforces SSO. Providers lists the SSO providers found for the
+// email with ScimService.SSOCheck. If the client was created with
+// WithSSORedirectURI, RedirectURL is the authorization URL that starts a
+// login with the first provider, so a login form can send the user there
+// instead of showing a password failure; otherwise pass a provider to
+// ScimService.OidcLogin. It matches ErrSSORequired with errors.Is, and
+// errors.As still finds the underlying *ApiError.
+type SSORequiredError struct {
+	Email       string
+	Providers   []SsoProvider
+	RedirectURL string
+	Err         *ApiError
+}
+
+func (e *SSORequiredError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrSSORequired, e.Err.Message)
+}
+
+func (e *SSORequiredError) Unwrap() []error {
+	return []error{ErrSSORequired, e.Err}
+}
+
+// MissingClaimError is returned by OAuth2Service.VerifyJWT when a claim
+// listed in VerifyOptions.RequireClaims is absent. It matches
+// ErrMissingClaim with errors.Is.
+type MissingClaimError struct {
+	Claim string
+}
+
+func (e *MissingClaimError) Error() string {
+	return fmt.Sprintf("%v %q", ErrMissingClaim, e.Claim)
+}
+
+func (e *MissingClaimError) Unwrap() error {
+	return ErrMissingClaim
+}
+
+// ClaimError is returned by OAuth2Service.VerifyIDToken when a correctly
+// signed token has a claim that fails validation. It matches
+// ErrInvalidClaims and ErrInvalidToken with errors.Is, and also Err if set,
+// e.g. ErrTokenExpired for an exp claim that has passed.
+type ClaimError struct {
+	Claim  string
+	Reason string
+	Err    error
+}
+
+func (e *ClaimError) Error() string {
+	return fmt.Sprintf("invalid %s claim: %s", e.Claim, e.Reason)
+}
+
+func (e *ClaimError) Unwrap() []error {
+	errs := []error{ErrInvalidClaims, ErrInvalidToken}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// ModelValidationError is returned instead of sending an authorization
+// model that fails ValidateModelSchema, by FgaService.Bootstrap and, under
+// WithModelValidation, by FgaService.WriteModel. Issues lists the problems
+// found.
+type ModelValidationError struct {
+	Issues []string
+}
+
+func (e *ModelValidationError) Error() string {
+	return "invalid authorization model: " + strings.Join(e.Issues, "; ")
+}
+
+// RetryError is returned when a call made under WithRetry failed after more
+// than one attempt. It unwraps to the error of the final attempt; Attempts
+// holds the error of every attempt in order, ending with the final one.
+type RetryError struct {
+	Err      error
+	Attempts []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %v", len(e.Attempts), e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
 // ApiError represents a non-2xx API response.
 type ApiError struct {
 	StatusCode int    `json:"status_code"`
 	ErrorCode  string `json:"error"`
 	Message    string `json:"message"`
+	// RetryAfter is the wait requested by the server via a Retry-After header
+	// or a retry_after body field, or zero if none was given.
+	RetryAfter time.Duration `json:"-"`
+	// Method and Path identify the request that failed, e.g. "POST" and
+	// "/api/fga/check". They are empty if the response did not record its
+	// request, as with a response made up by a middleware.
+	Method string `json:"-"`
+	Path   string `json:"-"`
 }
 
 func (e *ApiError) Error() string {
 	return fmt.Sprintf("[%d] %s: %s", e.StatusCode, e.ErrorCode, e.Message)
 }
 
+// Is reports whether the error matches one of the package's sentinel errors.
+func (e *ApiError) Is(target error) bool {
+	switch target {
+	case ErrPreconditionFailed:
+		return e.StatusCode == 412
+	case ErrInvalidGrant:
+		return e.ErrorCode == CodeInvalidGrant
+	case ErrRefreshTokenReuse:
+		return refreshTokenReuseCodes[e.ErrorCode]
+	case ErrSessionNotFound:
+		return e.ErrorCode == CodeSessionNotFound
+	case ErrAccessDenied:
+		return e.ErrorCode == CodeAccessDenied
+	}
+	return false
+}
+
 // IsNotFound returns true if the error is a 404.
 func IsNotFound(err error) bool {
 	if e, ok := err.(*ApiError); ok {
@@ -61,3 +361,25 @@ func IsRateLimited(err error) bool {
 	}
 	return false
 }
+
+// IsPreconditionFailed returns true if the error is a 412.
+func IsPreconditionFailed(err error) bool {
+	if e, ok := err.(*ApiError); ok {
+		return e.StatusCode == 412
+	}
+	return false
+}
+
+// IsMethodNotAllowed returns true if the error, or one it wraps, is a 405:
+// the server has the path but not the operation.
+func IsMethodNotAllowed(err error) bool {
+	var e *ApiError
+	return errors.As(err, &e) && e.StatusCode == 405
+}
+
+// IsNotImplemented returns true if the error, or one it wraps, is a 501:
+// the server does not support the operation.
+func IsNotImplemented(err error) bool {
+	var e *ApiError
+	return errors.As(err, &e) && e.StatusCode == 501
+}
@@ -0,0 +1,48 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+)
+
+// WithFeatureFallback controls what helpers built on optional server
+// endpoints do when the server lacks the endpoint. When enabled, such a
+// helper falls back to an equivalent per-item implementation, logging a
+// warning to the client's loggers the first time it does so for each
+// feature. When disabled, the default, the server's error is returned.
+//
+// FgaService.BatchCheck falls back to individual checks, and
+// FgaService.ListObjects and ListSubjects to checking the candidates found
+// in the store's tuples.
+func WithFeatureFallback(enabled bool) Option {
+	return func(c *Client) {
+		c.http.featureFallback = enabled
+	}
+}
+
+// isEndpointMissing reports whether err means the server does not implement
+// the endpoint, as opposed to it rejecting the request: a 405 or 501, or a
+// 404 without an error code (routers answer unknown paths with a bare 404,
+// while a missing resource comes with one).
+func isEndpointMissing(err error) bool {
+	if IsNotImplemented(err) || IsMethodNotAllowed(err) {
+		return true
+	}
+	var apiErr *ApiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == 404 && apiErr.ErrorCode == ""
+}
+
+// shouldFallback reports whether a helper whose optional endpoint failed
+// with err should fall back to its per-item implementation, warning once
+// per feature when it does.
+func (c *httpClient) shouldFallback(ctx context.Context, feature string, err error) bool {
+	if !c.featureFallback || !isEndpointMissing(err) {
+		return false
+	}
+	if _, warned := c.fallbackWarned.LoadOrStore(feature, true); !warned {
+		var apiErr *ApiError
+		errors.As(err, &apiErr)
+		c.warnf(ctx, "server does not support %s (%s %s: %d); falling back to per-item requests", feature, apiErr.Method, apiErr.Path, apiErr.StatusCode)
+	}
+	return true
+}
@@ -1,9 +1,14 @@
 package coreauth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // FgaService provides Fine-Grained Authorization (OpenFGA-compatible) operations.
@@ -18,26 +23,87 @@ func (s *FgaService) CreateTuple(ctx context.Context, data map[string]any) (json
 	return s.http.post(ctx, "/api/fga/tuples", data)
 }
 
+// CreateTupleInto is like CreateTuple but decodes the response into out.
+func (s *FgaService) CreateTupleInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateTuple(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // DeleteTuple removes an authorization tuple.
 func (s *FgaService) DeleteTuple(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/fga/tuples/delete", data)
 }
 
+// DeleteTupleInto is like DeleteTuple but decodes the response into out.
+func (s *FgaService) DeleteTupleInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.DeleteTuple(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // QueryTuples queries authorization tuples with optional filters.
 func (s *FgaService) QueryTuples(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/fga/tuples", params)
 }
 
+// QueryTuplesInto is like QueryTuples but decodes the response into out.
+func (s *FgaService) QueryTuplesInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.QueryTuples(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
 // GetObjectTuples returns all tuples for a specific object.
 func (s *FgaService) GetObjectTuples(ctx context.Context, objectType, objectID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/objects/%s:%s/tuples", objectType, objectID), nil)
 }
 
+// GetObjectTuplesInto is like GetObjectTuples but decodes the response into out.
+func (s *FgaService) GetObjectTuplesInto(ctx context.Context, objectType, objectID string, out any) error {
+	raw, err := s.GetObjectTuples(ctx, objectType, objectID)
+	return decodeResult(raw, err, out)
+}
+
+// GetObjectTuplesTyped returns all tuples for a specific object. An empty
+// result is an empty slice, never nil.
+func (s *FgaService) GetObjectTuplesTyped(ctx context.Context, objectType, objectID string) ([]RelationTuple, error) {
+	tuples := []RelationTuple{}
+	if err := s.GetObjectTuplesInto(ctx, objectType, objectID, &tuples); err != nil {
+		return nil, err
+	}
+	return tuples, nil
+}
+
 // GetSubjectTuples returns all tuples for a specific subject.
 func (s *FgaService) GetSubjectTuples(ctx context.Context, subjectType, subjectID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/subjects/%s:%s/tuples", subjectType, subjectID), nil)
 }
 
+// GetSubjectTuplesInto is like GetSubjectTuples but decodes the response into out.
+func (s *FgaService) GetSubjectTuplesInto(ctx context.Context, subjectType, subjectID string, out any) error {
+	raw, err := s.GetSubjectTuples(ctx, subjectType, subjectID)
+	return decodeResult(raw, err, out)
+}
+
+// GetSubjectTuplesTyped returns the tuples for a specific subject. If
+// relation is not empty, only tuples with that relation are returned, e.g.
+// the objects a user owns.
+func (s *FgaService) GetSubjectTuplesTyped(ctx context.Context, subjectType, subjectID, relation string) ([]RelationTuple, error) {
+	raw, err := s.http.get(ctx, fmt.Sprintf("/api/fga/subjects/%s:%s/tuples", subjectType, subjectID), map[string]string{"relation": relation})
+	if err != nil {
+		return nil, err
+	}
+	var tuples []RelationTuple
+	if err := decodeJSON(raw, &tuples); err != nil {
+		return nil, err
+	}
+	filtered := make([]RelationTuple, 0, len(tuples))
+	for _, t := range tuples {
+		if relation == "" || t.Relation == relation {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
 // --- Checks ---
 
 // Check evaluates whether a subject has a specific relation on an object.
@@ -45,16 +111,55 @@ func (s *FgaService) Check(ctx context.Context, data map[string]any) (json.RawMe
 	return s.http.post(ctx, "/api/fga/check", data)
 }
 
+// CheckInto is like Check but decodes the response into out.
+func (s *FgaService) CheckInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.Check(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
+// CheckTyped checks a permission. Parameters in conditions, if non-nil, are
+// added to req.Context, overriding entries with the same key.
+func (s *FgaService) CheckTyped(ctx context.Context, req CheckRequest, conditions *CheckContext) (*CheckResponse, error) {
+	if params := conditions.Map(); params != nil {
+		merged := make(map[string]any, len(req.Context)+len(params))
+		for k, v := range req.Context {
+			merged[k] = v
+		}
+		for k, v := range params {
+			merged[k] = v
+		}
+		req.Context = merged
+	}
+	raw, err := s.http.post(ctx, "/api/fga/check", req)
+	var resp CheckResponse
+	if err := decodeResult(raw, err, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Expand returns the expansion tree for a relation on an object.
 func (s *FgaService) Expand(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/fga/expand", data)
 }
 
+// ExpandInto is like Expand but decodes the response into out.
+func (s *FgaService) ExpandInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.Expand(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // ForwardAuth performs a permission check optimized for reverse-proxy forward-auth patterns.
 func (s *FgaService) ForwardAuth(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/fga/forward-auth", data)
 }
 
+// ForwardAuthInto is like ForwardAuth but decodes the response into out.
+func (s *FgaService) ForwardAuthInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.ForwardAuth(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // --- Stores ---
 
 // CreateStore creates a new FGA store.
@@ -62,19 +167,79 @@ func (s *FgaService) CreateStore(ctx context.Context, data map[string]any) (json
 	return s.http.post(ctx, "/api/fga/stores", data)
 }
 
+// CreateStoreInto is like CreateStore but decodes the response into out.
+func (s *FgaService) CreateStoreInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateStore(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
+// CreateStoreTyped creates a new FGA store and returns it.
+func (s *FgaService) CreateStoreTyped(ctx context.Context, req CreateStoreRequest) (*FgaStore, error) {
+	raw, err := s.http.post(ctx, "/api/fga/stores", req)
+	if err != nil {
+		return nil, err
+	}
+	var store FgaStore
+	if err := decodeJSON(raw, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
 // ListStores returns all FGA stores.
 func (s *FgaService) ListStores(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/fga/stores", nil)
 }
 
+// ListStoresInto is like ListStores but decodes the response into out.
+func (s *FgaService) ListStoresInto(ctx context.Context, out any) error {
+	raw, err := s.ListStores(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetStore retrieves an FGA store by ID.
 func (s *FgaService) GetStore(ctx context.Context, storeID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s", storeID), nil)
 }
 
+// GetStoreInto is like GetStore but decodes the response into out.
+func (s *FgaService) GetStoreInto(ctx context.Context, storeID string, out any) error {
+	raw, err := s.GetStore(ctx, storeID)
+	return decodeResult(raw, err, out)
+}
+
+// GetStoreTyped retrieves an FGA store by ID.
+func (s *FgaService) GetStoreTyped(ctx context.Context, storeID string) (*FgaStore, error) {
+	var store FgaStore
+	if err := s.GetStoreInto(ctx, storeID, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
 // UpdateStore updates an FGA store.
 func (s *FgaService) UpdateStore(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error) {
-	return s.http.put(ctx, fmt.Sprintf("/api/fga/stores/%s", storeID), data)
+	return s.http.patch(ctx, fmt.Sprintf("/api/fga/stores/%s", storeID), data)
+}
+
+// UpdateStoreInto is like UpdateStore but decodes the response into out.
+func (s *FgaService) UpdateStoreInto(ctx context.Context, storeID string, data map[string]any, out any) error {
+	raw, err := s.UpdateStore(ctx, storeID, data)
+	return decodeResult(raw, err, out)
+}
+
+// UpdateStoreTyped updates an FGA store, sending only the non-nil fields of
+// req, and returns the store as it is after the update.
+func (s *FgaService) UpdateStoreTyped(ctx context.Context, storeID string, req UpdateStoreRequest) (*FgaStore, error) {
+	raw, err := s.http.patch(ctx, fmt.Sprintf("/api/fga/stores/%s", storeID), req)
+	if err != nil {
+		return nil, err
+	}
+	var store FgaStore
+	if err := decodeJSON(raw, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
 }
 
 // DeleteStore removes an FGA store.
@@ -85,9 +250,40 @@ func (s *FgaService) DeleteStore(ctx context.Context, storeID string) error {
 
 // --- Models ---
 
-// WriteModel writes an authorization model to a store.
+// WriteModel writes an authorization model to a store. Under
+// WithModelValidation the model in data["schema"] is first checked with
+// ValidateModelSchema.
 func (s *FgaService) WriteModel(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error) {
-	return s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/models", storeID), data)
+	if s.http.validateModels {
+		if err := validateModelPayload(data["schema"]); err != nil {
+			return nil, err
+		}
+	}
+	return s.http.post(withConflictRetry(ctx), fmt.Sprintf("/api/fga/stores/%s/models", storeID), data)
+}
+
+// validateModelPayload runs ValidateModelSchema on a schema of any JSON
+// shape, returning a *ModelValidationError if it has issues.
+func validateModelPayload(schema any) error {
+	m, ok := schema.(map[string]any)
+	if !ok && schema != nil {
+		if b, err := json.Marshal(schema); err == nil {
+			_ = json.Unmarshal(b, &m)
+		}
+	}
+	if m == nil {
+		return &ModelValidationError{Issues: []string{"schema is missing or not an object"}}
+	}
+	if issues := ValidateModelSchema(m); len(issues) > 0 {
+		return &ModelValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// WriteModelInto is like WriteModel but decodes the response into out.
+func (s *FgaService) WriteModelInto(ctx context.Context, storeID string, data map[string]any, out any) error {
+	raw, err := s.WriteModel(ctx, storeID, data)
+	return decodeResult(raw, err, out)
 }
 
 // ListModels returns all authorization model versions for a store.
@@ -95,16 +291,63 @@ func (s *FgaService) ListModels(ctx context.Context, storeID string) (json.RawMe
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/models", storeID), nil)
 }
 
+// ListModelsInto is like ListModels but decodes the response into out.
+func (s *FgaService) ListModelsInto(ctx context.Context, storeID string, out any) error {
+	raw, err := s.ListModels(ctx, storeID)
+	return decodeResult(raw, err, out)
+}
+
 // GetCurrentModel retrieves the current (active) authorization model for a store.
 func (s *FgaService) GetCurrentModel(ctx context.Context, storeID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/models/current", storeID), nil)
 }
 
+// GetCurrentModelInto is like GetCurrentModel but decodes the response into out.
+func (s *FgaService) GetCurrentModelInto(ctx context.Context, storeID string, out any) error {
+	raw, err := s.GetCurrentModel(ctx, storeID)
+	return decodeResult(raw, err, out)
+}
+
 // GetModelVersion retrieves a specific authorization model version.
 func (s *FgaService) GetModelVersion(ctx context.Context, storeID, modelID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/models/%s", storeID, modelID), nil)
 }
 
+// GetModelVersionInto is like GetModelVersion but decodes the response into out.
+func (s *FgaService) GetModelVersionInto(ctx context.Context, storeID, modelID string, out any) error {
+	raw, err := s.GetModelVersion(ctx, storeID, modelID)
+	return decodeResult(raw, err, out)
+}
+
+// GetCurrentModelTyped retrieves the current (active) authorization model for a store.
+func (s *FgaService) GetCurrentModelTyped(ctx context.Context, storeID string) (*AuthorizationModel, error) {
+	var model AuthorizationModel
+	if err := s.GetCurrentModelInto(ctx, storeID, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// GetModelVersionTyped retrieves a specific authorization model version.
+// The server addresses models by their version number.
+func (s *FgaService) GetModelVersionTyped(ctx context.Context, storeID, modelID string) (*AuthorizationModel, error) {
+	var model AuthorizationModel
+	if err := s.GetModelVersionInto(ctx, storeID, modelID, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// IsCurrentModel reports whether modelID, given either as a model ID or as a
+// version number, identifies the store's current authorization model.
+func (s *FgaService) IsCurrentModel(ctx context.Context, storeID, modelID string) (bool, error) {
+	current, err := s.GetCurrentModelTyped(ctx, storeID)
+	if err != nil {
+		return false, err
+	}
+	return modelID == current.ID || modelID == strconv.Itoa(current.Version), nil
+}
+
 // --- API Keys ---
 
 // CreateAPIKey creates a new API key for an FGA store.
@@ -112,11 +355,32 @@ func (s *FgaService) CreateAPIKey(ctx context.Context, storeID string, data map[
 	return s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/api-keys", storeID), data)
 }
 
+// CreateAPIKeyInto is like CreateAPIKey but decodes the response into out.
+func (s *FgaService) CreateAPIKeyInto(ctx context.Context, storeID string, data map[string]any, out any) error {
+	raw, err := s.CreateAPIKey(ctx, storeID, data)
+	return decodeResult(raw, err, out)
+}
+
 // ListAPIKeys returns all API keys for an FGA store.
 func (s *FgaService) ListAPIKeys(ctx context.Context, storeID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/api-keys", storeID), nil)
 }
 
+// ListAPIKeysInto is like ListAPIKeys but decodes the response into out.
+func (s *FgaService) ListAPIKeysInto(ctx context.Context, storeID string, out any) error {
+	raw, err := s.ListAPIKeys(ctx, storeID)
+	return decodeResult(raw, err, out)
+}
+
+// ListAPIKeysTyped returns all API keys for an FGA store.
+func (s *FgaService) ListAPIKeysTyped(ctx context.Context, storeID string) ([]FgaStoreApiKey, error) {
+	var keys []FgaStoreApiKey
+	if err := s.ListAPIKeysInto(ctx, storeID, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
 // RevokeAPIKey revokes an API key for an FGA store.
 func (s *FgaService) RevokeAPIKey(ctx context.Context, storeID, keyID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/fga/stores/%s/api-keys/%s", storeID, keyID), nil)
@@ -130,12 +394,209 @@ func (s *FgaService) StoreCheck(ctx context.Context, storeID string, data map[st
 	return s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/check", storeID), data)
 }
 
+// StoreCheckInto is like StoreCheck but decodes the response into out.
+func (s *FgaService) StoreCheckInto(ctx context.Context, storeID string, data map[string]any, out any) error {
+	raw, err := s.StoreCheck(ctx, storeID, data)
+	return decodeResult(raw, err, out)
+}
+
+// batchCheckConcurrency bounds the number of checks BatchCheck runs at once
+// when it falls back to individual requests.
+const batchCheckConcurrency = 8
+
+// BatchCheck evaluates several checks against a store in one request and
+// returns the results in the order of reqs. The TenantID of each request is
+// ignored; the store scopes the checks.
+//
+// With WithFeatureFallback(true), a server without the batch endpoint is
+// sent the checks individually instead, a few at a time. Then a failed check
+// does not stop the others: its result is left false and the failures are
+// returned together, joined with errors.Join, each naming its index in reqs.
+func (s *FgaService) BatchCheck(ctx context.Context, storeID string, reqs []CheckRequest) ([]CheckResponse, error) {
+	if len(reqs) == 0 {
+		return []CheckResponse{}, nil
+	}
+	checks := make([]storeCheckRequest, len(reqs))
+	for i, r := range reqs {
+		checks[i] = r.toStoreCheck()
+	}
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/batch-check", storeID), map[string]any{"checks": checks})
+	if err != nil {
+		if s.http.shouldFallback(ctx, "batch check", err) {
+			return s.checkEach(ctx, storeID, checks)
+		}
+		return nil, err
+	}
+	var results []CheckResponse
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = decodeJSON(raw, &results)
+	} else {
+		var body struct {
+			Results []CheckResponse `json:"results"`
+		}
+		err = decodeJSON(raw, &body)
+		results = body.Results
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(reqs) {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("batch check returned %d results for %d checks", len(results), len(reqs))}
+	}
+	return results, nil
+}
+
+// checkEach runs checks one request each, at most batchCheckConcurrency at
+// a time, for BatchCheck.
+func (s *FgaService) checkEach(ctx context.Context, storeID string, checks []storeCheckRequest) ([]CheckResponse, error) {
+	results := make([]CheckResponse, len(checks))
+	errs := make([]error, len(checks))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(batchCheckConcurrency, len(checks)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/check", storeID), checks[i])
+				if err = decodeResult(raw, err, &results[i]); err != nil {
+					errs[i] = fmt.Errorf("check %d: %w", i, err)
+				}
+			}
+		}()
+	}
+	for i := range checks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
+
+// ListObjects returns the IDs of the objects of type req.Namespace on which
+// the subject has req.Relation, the reverse of a check.
+//
+// With WithFeatureFallback(true), a server without the list-objects
+// endpoint is answered client-side instead: every object of the type that
+// appears in a tuple of the store is checked with BatchCheck. Objects
+// without any tuple are never considered.
+func (s *FgaService) ListObjects(ctx context.Context, storeID string, req ListObjectsRequest) ([]string, error) {
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/list-objects", storeID), req)
+	if err != nil {
+		if !s.http.shouldFallback(ctx, "list objects", err) {
+			return nil, err
+		}
+		tuples, err := s.ListAllStoreTuples(ctx, storeID, map[string]string{"object_type": req.Namespace})
+		if err != nil {
+			return nil, err
+		}
+		return s.checkCandidates(ctx, storeID, distinctTupleKeys(tuples, func(t RelationTuple) string { return t.ObjectID }), func(id string) CheckRequest {
+			return CheckRequest{SubjectType: req.SubjectType, SubjectID: req.SubjectID, Relation: req.Relation, Namespace: req.Namespace, ObjectID: id, Context: req.Context}
+		})
+	}
+	return decodeIDList(raw, "objects", req.Namespace)
+}
+
+// ListSubjects returns the IDs of the subjects of type req.SubjectType that
+// have req.Relation on the object, answering "who can access this".
+//
+// With WithFeatureFallback(true), a server without the list-subjects
+// endpoint is answered client-side instead: every subject of the type that
+// appears in a tuple of the store is checked with BatchCheck. Subjects
+// without any tuple are never considered.
+func (s *FgaService) ListSubjects(ctx context.Context, storeID string, req ListSubjectsRequest) ([]string, error) {
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/list-subjects", storeID), req)
+	if err != nil {
+		if !s.http.shouldFallback(ctx, "list subjects", err) {
+			return nil, err
+		}
+		tuples, err := s.ListAllStoreTuples(ctx, storeID, map[string]string{"subject_type": req.SubjectType})
+		if err != nil {
+			return nil, err
+		}
+		return s.checkCandidates(ctx, storeID, distinctTupleKeys(tuples, func(t RelationTuple) string { return t.SubjectID }), func(id string) CheckRequest {
+			return CheckRequest{SubjectType: req.SubjectType, SubjectID: id, Relation: req.Relation, Namespace: req.Namespace, ObjectID: req.ObjectID, Context: req.Context}
+		})
+	}
+	return decodeIDList(raw, "subjects", req.SubjectType)
+}
+
+// checkCandidates checks each candidate ID with the request built by check
+// and returns the IDs that are allowed, in order.
+func (s *FgaService) checkCandidates(ctx context.Context, storeID string, ids []string, check func(id string) CheckRequest) ([]string, error) {
+	reqs := make([]CheckRequest, len(ids))
+	for i, id := range ids {
+		reqs[i] = check(id)
+	}
+	results, err := s.BatchCheck(ctx, storeID, reqs)
+	if err != nil {
+		return nil, err
+	}
+	allowed := []string{}
+	for i, r := range results {
+		if r.Allowed {
+			allowed = append(allowed, ids[i])
+		}
+	}
+	return allowed, nil
+}
+
+// distinctTupleKeys returns the non-empty keys of tuples, without duplicates, in
+// order of first appearance.
+func distinctTupleKeys(tuples []RelationTuple, key func(RelationTuple) string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, t := range tuples {
+		if k := key(t); k != "" && !seen[k] {
+			seen[k] = true
+			ids = append(ids, k)
+		}
+	}
+	return ids
+}
+
+// decodeIDList decodes a list-objects or list-subjects response, either a
+// bare array or an object holding the array under field. OpenFGA-style
+// "type:id" entries are reduced to the ID.
+func decodeIDList(raw json.RawMessage, field, typ string) ([]string, error) {
+	var ids []string
+	var err error
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = decodeJSON(raw, &ids)
+	} else {
+		var body map[string]json.RawMessage
+		if err = decodeJSON(raw, &body); err == nil {
+			err = decodeJSON(body[field], &ids)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = strings.TrimPrefix(id, typ+":")
+	}
+	return out, nil
+}
+
 // ReadStoreTuples reads tuples from a specific store.
 func (s *FgaService) ReadStoreTuples(ctx context.Context, storeID string, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/tuples", storeID), params)
 }
 
+// ReadStoreTuplesInto is like ReadStoreTuples but decodes the response into out.
+func (s *FgaService) ReadStoreTuplesInto(ctx context.Context, storeID string, params map[string]string, out any) error {
+	raw, err := s.ReadStoreTuples(ctx, storeID, params)
+	return decodeResult(raw, err, out)
+}
+
 // WriteStoreTuples writes tuples to a specific store.
 func (s *FgaService) WriteStoreTuples(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/tuples", storeID), data)
 }
+
+// WriteStoreTuplesInto is like WriteStoreTuples but decodes the response into out.
+func (s *FgaService) WriteStoreTuplesInto(ctx context.Context, storeID string, data map[string]any, out any) error {
+	raw, err := s.WriteStoreTuples(ctx, storeID, data)
+	return decodeResult(raw, err, out)
+}
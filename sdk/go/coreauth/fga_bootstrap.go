@@ -0,0 +1,86 @@
+package coreauth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// bootstrapRollbackTimeout bounds the cleanup after a failed Bootstrap.
+const bootstrapRollbackTimeout = 30 * time.Second
+
+// Bootstrap creates a store, writes its initial authorization model, and
+// seeds it with tuples in one call. The tuples, and under
+// WithModelValidation the model, are validated locally before anything is
+// created. If a later step fails, including a tuple the server skips, the
+// store is hard-deleted and a *BootstrapError describes the failed step and
+// the outcome of the rollback.
+func (s *FgaService) Bootstrap(ctx context.Context, req BootstrapRequest) (*BootstrapResult, error) {
+	schema := req.Schema
+	switch {
+	case req.ModelDSL != "" && schema != nil:
+		return nil, &ValidationError{Field: "model", Message: "set either Schema or ModelDSL, not both"}
+	case req.ModelDSL != "":
+		var err error
+		if schema, err = ParseModelDSL(req.ModelDSL); err != nil {
+			return nil, err
+		}
+	case schema == nil:
+		return nil, &ValidationError{Field: "model", Message: "an authorization model is required"}
+	}
+	if s.http.validateModels {
+		if issues := ValidateModelSchema(schema); len(issues) > 0 {
+			return nil, &ModelValidationError{Issues: issues}
+		}
+	}
+	for i, t := range req.Tuples {
+		if err := validateStoreTuple(t); err != nil {
+			return nil, &ValidationError{Field: "tuples", Message: fmt.Sprintf("tuple %d: %v", i, err)}
+		}
+	}
+
+	store, err := s.CreateStoreTyped(ctx, CreateStoreRequest{Name: req.Name, Description: req.Description})
+	if err != nil {
+		return nil, &BootstrapError{Step: "create_store", Err: err}
+	}
+	result := &BootstrapResult{StoreID: store.ID}
+
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/models", store.ID), WriteModelRequest{Schema: schema, CreatedBy: req.CreatedBy})
+	var model AuthorizationModel
+	if err == nil {
+		err = decodeJSON(raw, &model)
+	}
+	if err != nil {
+		return nil, s.rollbackBootstrap(ctx, "write_model", store.ID, err)
+	}
+	result.ModelID = model.ID
+	result.ModelVersion = model.Version
+
+	for start := 0; start < len(req.Tuples); start += defaultTupleChunkSize {
+		end := min(start+defaultTupleChunkSize, len(req.Tuples))
+		resp, err := s.writeTupleChunk(ctx, store.ID, req.Tuples[start:end], nil)
+		if err == nil {
+			err = resp.checkApplied(end-start, 0)
+		}
+		if err != nil {
+			return nil, s.rollbackBootstrap(ctx, "write_tuples", store.ID, err)
+		}
+		result.TuplesWritten += resp.Written
+	}
+	return result, nil
+}
+
+// rollbackBootstrap hard-deletes a partially bootstrapped store. It runs even
+// if ctx was cancelled, since cancellation is a common cause of the failure.
+func (s *FgaService) rollbackBootstrap(ctx context.Context, step, storeID string, cause error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), bootstrapRollbackTimeout)
+	defer cancel()
+	bootErr := &BootstrapError{Step: step, StoreID: storeID, Err: cause}
+	_, err := s.http.del(ctx, fmt.Sprintf("/api/fga/stores/%s?hard_delete=true", storeID), nil)
+	if err != nil {
+		bootErr.RollbackErr = err
+	} else {
+		bootErr.RolledBack = true
+	}
+	return bootErr
+}
@@ -0,0 +1,518 @@
+package coreauth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Supported tuple file formats for ImportTuples and ExportTuples.
+const (
+	TupleFormatNDJSON = "ndjson"
+	TupleFormatCSV    = "csv"
+)
+
+// defaultTupleChunkSize is the number of tuples sent per write request.
+const defaultTupleChunkSize = 100
+
+// tupleCSVHeader is the column order used for CSV tuple files.
+var tupleCSVHeader = []string{"object_type", "object_id", "relation", "subject_type", "subject_id", "subject_relation"}
+
+// ImportTuples reads tuples from r and writes them to a store in chunks.
+// The format is either TupleFormatNDJSON (one JSON tuple per line) or
+// TupleFormatCSV (columns in tupleCSVHeader order, header row optional).
+// Malformed lines and failed chunks are recorded in the result's Errors and
+// the import continues.
+//
+// A failed chunk is counted as skipped in full, but the server writes a
+// chunk's tuples one at a time without a transaction, so the tuples before
+// the one it rejected may already be stored. Re-importing the file is
+// safe: the server skips tuples that already exist.
+func (s *FgaService) ImportTuples(ctx context.Context, storeID string, r io.Reader, format string) (*BatchResult, error) {
+	return s.ImportTuplesWithProgress(ctx, storeID, r, format, nil)
+}
+
+// ImportTuplesWithProgress is like ImportTuples but calls progress, if not
+// nil, after each chunk with the running totals.
+func (s *FgaService) ImportTuplesWithProgress(ctx context.Context, storeID string, r io.Reader, format string, progress BatchProgressFunc) (*BatchResult, error) {
+	next, err := newTupleReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{}
+	chunk := make([]StoreTuple, 0, defaultTupleChunkSize)
+	chunkIndex := 0
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		resp, err := s.writeTupleChunk(ctx, storeID, chunk, nil)
+		if err != nil {
+			result.Skipped += len(chunk)
+			result.Errors = append(result.Errors, BatchError{Chunk: chunkIndex, Err: err})
+		} else {
+			result.Written += resp.Written
+			result.Skipped += len(chunk) - resp.Written
+		}
+		chunkIndex++
+		chunk = chunk[:0]
+		if progress != nil {
+			progress(result.Written, result.Skipped)
+		}
+		return nil
+	}
+
+	for {
+		t, err := next()
+		if err == io.EOF {
+			break
+		}
+		var lineErr *tupleLineError
+		if errors.As(err, &lineErr) {
+			result.Skipped++
+			result.Errors = append(result.Errors, BatchError{Line: lineErr.line, Chunk: -1, Err: lineErr.err})
+			continue
+		}
+		if err != nil {
+			return result, &CoreAuthError{Message: fmt.Sprintf("failed to read tuples: %v", err)}
+		}
+		if t == nil {
+			continue
+		}
+		chunk = append(chunk, *t)
+		if len(chunk) == defaultTupleChunkSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// WriteTuplesBatched writes and deletes store tuples in sequential requests
+// of at most chunkSize operations each (100 if chunkSize is not positive),
+// for backends that cap the size of a single WriteStoreTuples call. Writes
+// are sent before deletes; a chunk may carry both. A failed chunk does not
+// stop the others: the result counts what the server reports as written and
+// deleted, its Errors hold a BatchError per failed chunk, and the returned
+// error joins them, so callers can tell which chunks to retry. It stops
+// early only if ctx is done.
+func (s *FgaService) WriteTuplesBatched(ctx context.Context, storeID string, writes, deletes []map[string]any, chunkSize int) (*BatchResult, error) {
+	if chunkSize <= 0 {
+		chunkSize = defaultTupleChunkSize
+	}
+	result := &BatchResult{}
+	var errs []error
+	total := len(writes) + len(deletes)
+	for chunk, start := 0, 0; start < total; chunk, start = chunk+1, start+chunkSize {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		end := min(start+chunkSize, total)
+		body := map[string]any{"writes": []map[string]any{}}
+		if w := writes[min(start, len(writes)):min(end, len(writes))]; len(w) > 0 {
+			body["writes"] = w
+		}
+		if d := deletes[max(start-len(writes), 0):max(end-len(writes), 0)]; len(d) > 0 {
+			body["deletes"] = d
+		}
+		var resp WriteTuplesResponse
+		if err := s.WriteStoreTuplesInto(ctx, storeID, body, &resp); err != nil {
+			result.Skipped += end - start
+			batchErr := BatchError{Chunk: chunk, Err: err}
+			result.Errors = append(result.Errors, batchErr)
+			errs = append(errs, batchErr)
+			continue
+		}
+		result.Written += resp.Written
+		result.Deleted += resp.Deleted
+	}
+	return result, errors.Join(errs...)
+}
+
+// ExportTuples streams every tuple in a store to w in the given format
+// (TupleFormatNDJSON or TupleFormatCSV), following pagination. Output is
+// flushed after each page so a partial export is still readable if ctx is
+// cancelled or a later page fails. Gzip-encoded pages are decompressed
+// transparently.
+func (s *FgaService) ExportTuples(ctx context.Context, storeID string, w io.Writer, format string) error {
+	write, flush, err := newTupleWriter(w, format)
+	if err != nil {
+		return err
+	}
+	pages := s.StoreTuplesPaginator(storeID, nil, defaultTupleChunkSize)
+	for pages.More() {
+		tuples, err := pages.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, t := range tuples {
+			if err := write(t.toStoreTuple()); err != nil {
+				return &CoreAuthError{Message: fmt.Sprintf("failed to write tuple: %v", err)}
+			}
+		}
+		if err := flush(); err != nil {
+			return &CoreAuthError{Message: fmt.Sprintf("failed to flush tuples: %v", err)}
+		}
+	}
+	return nil
+}
+
+// DiffTuples compares the current tuples with the desired ones and returns
+// the tuples to write and to delete to get from one to the other, each
+// sorted by canonical form (see CreateTupleRequest.String). Tuples are
+// compared by canonical form, so TenantID is ignored and duplicates count
+// once.
+func DiffTuples(current, desired []CreateTupleRequest) (toAdd, toRemove []CreateTupleRequest) {
+	toAdd, toRemove, _ = diffTuples(current, desired)
+	return toAdd, toRemove
+}
+
+// diffTuples is DiffTuples, also returning the canonical forms of the tuples
+// present in both sets.
+func diffTuples(current, desired []CreateTupleRequest) (toAdd, toRemove []CreateTupleRequest, unchanged []string) {
+	byKey := make(map[string]CreateTupleRequest, len(current)+len(desired))
+	keys := func(tuples []CreateTupleRequest) []string {
+		out := make([]string, len(tuples))
+		for i, t := range tuples {
+			out[i] = t.String()
+			byKey[out[i]] = t
+		}
+		return out
+	}
+	add, remove, unchanged := diffSets(keys(current), keys(desired))
+	for _, k := range add {
+		toAdd = append(toAdd, byKey[k])
+	}
+	for _, k := range remove {
+		toRemove = append(toRemove, byKey[k])
+	}
+	return toAdd, toRemove, unchanged
+}
+
+// SyncTuples makes the tuples of a store exactly the desired set: it reads
+// every current tuple, writes the missing ones and deletes the rest, in
+// chunks of at most 100 tuples per request. Tuples are identified in the
+// result by canonical form. A failed chunk does not stop the sync; each of
+// its tuples is listed in the result's Errors, and the result's Err method
+// joins them. The server skips tuples it cannot apply without failing the
+// request, so a chunk it reports as only partly applied counts as failed,
+// although some of its tuples may have been applied. The returned error is set only if the current tuples cannot
+// be read or ctx is done, in which case the result covers the chunks
+// applied so far.
+func (s *FgaService) SyncTuples(ctx context.Context, storeID string, desired []CreateTupleRequest) (*ReconcileResult, error) {
+	existing, err := s.ListAllStoreTuples(ctx, storeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]CreateTupleRequest, len(existing))
+	for i, t := range existing {
+		current[i] = CreateTupleRequest{
+			Namespace:       t.Namespace,
+			ObjectID:        t.ObjectID,
+			Relation:        t.Relation,
+			SubjectType:     t.SubjectType,
+			SubjectID:       t.SubjectID,
+			SubjectRelation: t.SubjectRelation,
+		}
+	}
+	toAdd, toRemove, unchanged := diffTuples(current, desired)
+
+	result := &ReconcileResult{Unchanged: unchanged}
+	apply := func(tuples []CreateTupleRequest, done *[]string, deleting bool) error {
+		for start := 0; start < len(tuples); start += defaultTupleChunkSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			chunk := tuples[start:min(start+defaultTupleChunkSize, len(tuples))]
+			storeTuples := make([]StoreTuple, len(chunk))
+			for i, t := range chunk {
+				storeTuples[i] = t.toStoreTuple()
+			}
+			var (
+				resp *WriteTuplesResponse
+				err  error
+			)
+			if deleting {
+				resp, err = s.writeTupleChunk(ctx, storeID, nil, storeTuples)
+				if err == nil {
+					err = resp.checkApplied(0, len(chunk))
+				}
+			} else {
+				resp, err = s.writeTupleChunk(ctx, storeID, storeTuples, nil)
+				if err == nil {
+					err = resp.checkApplied(len(chunk), 0)
+				}
+			}
+			for _, t := range chunk {
+				key := t.String()
+				if err != nil {
+					result.Errors = append(result.Errors, ReconcileError{Item: key, Err: err})
+				} else {
+					*done = append(*done, key)
+				}
+			}
+		}
+		return nil
+	}
+	if err := apply(toAdd, &result.Added, false); err != nil {
+		return result, err
+	}
+	if err := apply(toRemove, &result.Removed, true); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// StoreTuplesPaginator returns a Paginator over the tuples in a store that
+// match params, fetching pageSize tuples per request.
+func (s *FgaService) StoreTuplesPaginator(storeID string, params map[string]string, pageSize int) *Paginator[RelationTuple] {
+	return NewPaginator(func(ctx context.Context, cursor string) ([]RelationTuple, string, error) {
+		return s.readStoreTuplePage(ctx, storeID, params, pageSize, cursor)
+	})
+}
+
+// ListAllStoreTuples returns every tuple in a store that matches params,
+// following pagination.
+func (s *FgaService) ListAllStoreTuples(ctx context.Context, storeID string, params map[string]string) ([]RelationTuple, error) {
+	return s.StoreTuplesPaginator(storeID, params, defaultPageSize).All(ctx)
+}
+
+// QueryTuplesPage fetches one page of at most pageSize tuples matching
+// filter, starting at continuation ("" for the first page), and returns the
+// tuples along with the continuation token of the next page, or "" after the
+// last page. Unlike the paginators, it leaves the cursor to the caller, who
+// can persist it and resume later.
+func (s *FgaService) QueryTuplesPage(ctx context.Context, filter QueryTuplesRequest, pageSize int, continuation string) ([]RelationTuple, string, error) {
+	q := filter.params()
+	if pageSize > 0 {
+		q["page_size"] = strconv.Itoa(pageSize)
+	}
+	if continuation != "" {
+		q["continuation_token"] = continuation
+	}
+	raw, err := s.QueryTuples(ctx, q)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeTuplePage(raw)
+}
+
+// readStoreTuplePage fetches one page of tuples from a store. Servers that do
+// not paginate return a bare array, which is treated as the final page.
+func (s *FgaService) readStoreTuplePage(ctx context.Context, storeID string, params map[string]string, pageSize int, token string) ([]RelationTuple, string, error) {
+	q := copyParams(params)
+	if pageSize > 0 {
+		q["page_size"] = strconv.Itoa(pageSize)
+	}
+	if token != "" {
+		q["continuation_token"] = token
+	}
+	raw, err := s.http.get(ctx, fmt.Sprintf("/api/fga/stores/%s/tuples", storeID), q)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeTuplePage(raw)
+}
+
+// decodeTuplePage decodes either a bare tuple array or a paginated
+// {"tuples": [...], "continuation_token": "..."} object.
+func decodeTuplePage(raw json.RawMessage) ([]RelationTuple, string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return []RelationTuple{}, "", nil
+	}
+	if trimmed[0] == '[' {
+		var tuples []RelationTuple
+		if err := json.Unmarshal(trimmed, &tuples); err != nil {
+			return nil, "", &CoreAuthError{Message: fmt.Sprintf("failed to decode tuples: %v", err)}
+		}
+		return tuples, "", nil
+	}
+	var page struct {
+		Tuples            []RelationTuple `json:"tuples"`
+		ContinuationToken string          `json:"continuation_token"`
+	}
+	if err := json.Unmarshal(trimmed, &page); err != nil {
+		return nil, "", &CoreAuthError{Message: fmt.Sprintf("failed to decode tuples: %v", err)}
+	}
+	if page.Tuples == nil {
+		page.Tuples = []RelationTuple{}
+	}
+	return page.Tuples, page.ContinuationToken, nil
+}
+
+// writeTupleChunk writes a single chunk of tuples to a store.
+func (s *FgaService) writeTupleChunk(ctx context.Context, storeID string, writes, deletes []StoreTuple) (*WriteTuplesResponse, error) {
+	body := struct {
+		Writes  []StoreTuple `json:"writes"`
+		Deletes []StoreTuple `json:"deletes,omitempty"`
+	}{Writes: writes, Deletes: deletes}
+	if body.Writes == nil {
+		body.Writes = []StoreTuple{}
+	}
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/fga/stores/%s/tuples", storeID), body)
+	if err != nil {
+		return nil, err
+	}
+	var resp WriteTuplesResponse
+	if err := decodeJSON(raw, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// checkApplied returns an error if the server applied fewer than the given
+// numbers of writes and deletes. The server skips tuples it cannot apply
+// and still reports success, so only the counts reveal them.
+func (r *WriteTuplesResponse) checkApplied(writes, deletes int) error {
+	if r.Written < writes || r.Deleted < deletes {
+		return &CoreAuthError{Message: fmt.Sprintf("server applied %d of %d writes and %d of %d deletes", r.Written, writes, r.Deleted, deletes)}
+	}
+	return nil
+}
+
+// tupleLineError reports a malformed line in a tuple file.
+type tupleLineError struct {
+	line int
+	err  error
+}
+
+func (e *tupleLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+// newTupleReader returns a function yielding one tuple per call. It returns
+// (nil, nil) for lines that carry no tuple, a *tupleLineError for malformed
+// lines, and io.EOF at the end of input.
+func newTupleReader(r io.Reader, format string) (func() (*StoreTuple, error), error) {
+	switch strings.ToLower(format) {
+	case TupleFormatNDJSON, "jsonl", "json":
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		line := 0
+		return func() (*StoreTuple, error) {
+			if !sc.Scan() {
+				if err := sc.Err(); err != nil {
+					return nil, err
+				}
+				return nil, io.EOF
+			}
+			line++
+			text := strings.TrimSpace(sc.Text())
+			if text == "" {
+				return nil, nil
+			}
+			var t StoreTuple
+			if err := json.Unmarshal([]byte(text), &t); err != nil {
+				return nil, &tupleLineError{line: line, err: err}
+			}
+			if err := validateStoreTuple(t); err != nil {
+				return nil, &tupleLineError{line: line, err: err}
+			}
+			return &t, nil
+		}, nil
+	case TupleFormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		return func() (*StoreTuple, error) {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &tupleLineError{line: parseErr.Line, err: parseErr.Err}
+			}
+			if err != nil {
+				return nil, err
+			}
+			line, _ := cr.FieldPos(0)
+			if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), tupleCSVHeader[0]) {
+				return nil, nil
+			}
+			if len(record) < 5 || len(record) > len(tupleCSVHeader) {
+				return nil, &tupleLineError{line: line, err: fmt.Errorf("expected 5 or 6 fields, got %d", len(record))}
+			}
+			t := StoreTuple{
+				ObjectType:  record[0],
+				ObjectID:    record[1],
+				Relation:    record[2],
+				SubjectType: record[3],
+				SubjectID:   record[4],
+			}
+			if len(record) == 6 && record[5] != "" {
+				rel := record[5]
+				t.SubjectRelation = &rel
+			}
+			if err := validateStoreTuple(t); err != nil {
+				return nil, &tupleLineError{line: line, err: err}
+			}
+			return &t, nil
+		}, nil
+	default:
+		return nil, &CoreAuthError{Message: fmt.Sprintf("unsupported tuple format %q", format)}
+	}
+}
+
+// newTupleWriter returns functions that encode a tuple to w and flush any
+// buffered output.
+func newTupleWriter(w io.Writer, format string) (func(StoreTuple) error, func() error, error) {
+	switch strings.ToLower(format) {
+	case TupleFormatNDJSON, "jsonl", "json":
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		return func(t StoreTuple) error { return enc.Encode(t) }, bw.Flush, nil
+	case TupleFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(tupleCSVHeader); err != nil {
+			return nil, nil, &CoreAuthError{Message: fmt.Sprintf("failed to write header: %v", err)}
+		}
+		write := func(t StoreTuple) error {
+			subjectRelation := ""
+			if t.SubjectRelation != nil {
+				subjectRelation = *t.SubjectRelation
+			}
+			return cw.Write([]string{t.ObjectType, t.ObjectID, t.Relation, t.SubjectType, t.SubjectID, subjectRelation})
+		}
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		return write, flush, nil
+	default:
+		return nil, nil, &CoreAuthError{Message: fmt.Sprintf("unsupported tuple format %q", format)}
+	}
+}
+
+func validateStoreTuple(t StoreTuple) error {
+	switch {
+	case t.ObjectType == "":
+		return errors.New("missing object_type")
+	case t.ObjectID == "":
+		return errors.New("missing object_id")
+	case t.Relation == "":
+		return errors.New("missing relation")
+	case t.SubjectType == "":
+		return errors.New("missing subject_type")
+	case t.SubjectID == "":
+		return errors.New("missing subject_id")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package coreauth
+
+import (
+	"net/netip"
+	"time"
+)
+
+// CheckContext builds the Context of a conditional CheckRequest, encoding
+// each value in the format FGA conditions expect. Setters return the builder
+// so calls can be chained.
+type CheckContext struct {
+	values map[string]any
+}
+
+// NewCheckContext returns an empty CheckContext.
+func NewCheckContext() *CheckContext {
+	return &CheckContext{values: make(map[string]any)}
+}
+
+// SetString sets a string condition parameter.
+func (c *CheckContext) SetString(key, value string) *CheckContext {
+	return c.set(key, value)
+}
+
+// SetInt sets an integer condition parameter.
+func (c *CheckContext) SetInt(key string, value int64) *CheckContext {
+	return c.set(key, value)
+}
+
+// SetBool sets a boolean condition parameter.
+func (c *CheckContext) SetBool(key string, value bool) *CheckContext {
+	return c.set(key, value)
+}
+
+// SetTime sets a timestamp condition parameter as an RFC 3339 string in UTC.
+func (c *CheckContext) SetTime(key string, value time.Time) *CheckContext {
+	return c.set(key, value.UTC().Format(time.RFC3339))
+}
+
+// SetIPAddress sets an IP address condition parameter in its canonical text
+// form, with any IPv4-mapped IPv6 address unmapped to plain IPv4.
+func (c *CheckContext) SetIPAddress(key string, value netip.Addr) *CheckContext {
+	return c.set(key, value.Unmap().String())
+}
+
+// SetCIDR sets a network condition parameter as a CIDR string, with host
+// bits cleared (e.g. "10.1.2.3/8" becomes "10.0.0.0/8").
+func (c *CheckContext) SetCIDR(key string, value netip.Prefix) *CheckContext {
+	return c.set(key, value.Masked().String())
+}
+
+// Map returns a copy of the parameters, ready for CheckRequest.Context, or
+// nil if none are set. It is safe to call on a nil *CheckContext.
+func (c *CheckContext) Map() map[string]any {
+	if c == nil || len(c.values) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(c.values))
+	for k, v := range c.values {
+		m[k] = v
+	}
+	return m
+}
+
+func (c *CheckContext) set(key string, value any) *CheckContext {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[key] = value
+	return c
+}
@@ -0,0 +1,509 @@
+package coreauth
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ParseModelDSL converts an authorization model written in the OpenFGA DSL
+// into the JSON schema accepted by WriteModel. Comments starting with "#" or
+// "//" and blank lines are ignored. Relation expressions may combine direct
+// types ("[user, group#member, user:*]"), other relations, "rel from parent",
+// "or", "and", "but not", and parentheses. Errors are returned as a
+// *ValidationError naming the offending line.
+func ParseModelDSL(dsl string) (map[string]any, error) {
+	schemaVersion := "1.1"
+	var typeDefs []any
+	var current map[string]any
+
+	for i, rawLine := range strings.Split(dsl, "\n") {
+		lineNo := i + 1
+		line := stripDSLComment(rawLine)
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "model":
+			if len(fields) != 1 {
+				return nil, dslError(lineNo, "unexpected text after \"model\"")
+			}
+		case "schema":
+			if len(fields) != 2 {
+				return nil, dslError(lineNo, "expected \"schema <version>\"")
+			}
+			schemaVersion = fields[1]
+		case "type":
+			if len(fields) != 2 {
+				return nil, dslError(lineNo, "expected \"type <name>\"")
+			}
+			current = map[string]any{"type": fields[1], "relations": map[string]any{}}
+			typeDefs = append(typeDefs, current)
+		case "relations":
+			if current == nil {
+				return nil, dslError(lineNo, "\"relations\" outside of a type")
+			}
+		case "define":
+			if current == nil {
+				return nil, dslError(lineNo, "\"define\" outside of a type")
+			}
+			name, expr, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "define")), ":")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, dslError(lineNo, "expected \"define <relation>: <expression>\"")
+			}
+			relations := current["relations"].(map[string]any)
+			if _, dup := relations[name]; dup {
+				return nil, dslError(lineNo, fmt.Sprintf("relation %q defined twice on type %q", name, current["type"]))
+			}
+			def, err := parseRelationExpr(expr)
+			if err != nil {
+				return nil, dslError(lineNo, err.Error())
+			}
+			relations[name] = def
+		default:
+			return nil, dslError(lineNo, fmt.Sprintf("unexpected %q", fields[0]))
+		}
+	}
+	if len(typeDefs) == 0 {
+		return nil, &ValidationError{Field: "dsl", Message: "model defines no types"}
+	}
+	return map[string]any{
+		"schema_version":   schemaVersion,
+		"type_definitions": typeDefs,
+	}, nil
+}
+
+func dslError(line int, msg string) error {
+	return &ValidationError{Field: "dsl", Message: fmt.Sprintf("line %d: %s", line, msg)}
+}
+
+// stripDSLComment removes a trailing "#" or "//" comment and surrounding
+// space. A "#" only starts a comment at the start of a line or after
+// whitespace, so userset references such as group#member are kept.
+func stripDSLComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimSpace(line[:i])
+		case line[i] == '/' && i+1 < len(line) && line[i+1] == '/':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// relationParser is a recursive-descent parser for relation expressions.
+type relationParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseRelationExpr(expr string) (map[string]any, error) {
+	p := &relationParser{tokens: tokenizeRelationExpr(expr)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty relation expression")
+	}
+	def, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return def, nil
+}
+
+func tokenizeRelationExpr(expr string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range expr {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case strings.ContainsRune("[](),", r):
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func (p *relationParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *relationParser) next() string {
+	t := p.peek()
+	if t != "" {
+		p.pos++
+	}
+	return t
+}
+
+// expr := term (("or" term)* | ("and" term)*) ["but" "not" term]
+func (p *relationParser) expr() (map[string]any, error) {
+	first, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	def := first
+	if op := p.peek(); op == "or" || op == "and" {
+		children := []any{first}
+		for p.peek() == op {
+			p.next()
+			child, err := p.term()
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		if other := p.peek(); other == "or" || other == "and" {
+			return nil, fmt.Errorf("cannot mix \"or\" and \"and\" without parentheses")
+		}
+		key := "union"
+		if op == "and" {
+			key = "intersection"
+		}
+		def = map[string]any{key: children}
+	}
+	if p.peek() == "but" {
+		p.next()
+		if p.next() != "not" {
+			return nil, fmt.Errorf("expected \"not\" after \"but\"")
+		}
+		subtract, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		def = map[string]any{"exclusion": map[string]any{"base": def, "subtract": subtract}}
+	}
+	return def, nil
+}
+
+// term := "[" types "]" | relation "from" relation | relation | "(" expr ")"
+func (p *relationParser) term() (map[string]any, error) {
+	switch tok := p.next(); tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "[":
+		types := []any{}
+		for {
+			t := p.next()
+			switch t {
+			case "":
+				return nil, fmt.Errorf("missing \"]\"")
+			case "]":
+				if len(types) == 0 {
+					return nil, fmt.Errorf("empty type list")
+				}
+				return map[string]any{"this": map[string]any{"types": types}}, nil
+			case ",":
+				continue
+			}
+			types = append(types, t)
+		}
+	case "(":
+		def, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing \")\"")
+		}
+		return def, nil
+	case "]", ")", ",", "or", "and", "but", "not", "from":
+		return nil, fmt.Errorf("unexpected %q", tok)
+	default:
+		if p.peek() == "from" {
+			p.next()
+			tupleset := p.next()
+			if !isDSLIdentifier(tupleset) {
+				return nil, fmt.Errorf("expected relation after \"from\"")
+			}
+			return map[string]any{"tuple_to_userset": map[string]any{
+				"tupleset":         map[string]any{"relation": tupleset},
+				"computed_userset": map[string]any{"relation": tok},
+			}}, nil
+		}
+		if !isDSLIdentifier(tok) {
+			return nil, fmt.Errorf("invalid relation name %q", tok)
+		}
+		return map[string]any{"computed_userset": map[string]any{"relation": tok}}, nil
+	}
+}
+
+func isDSLIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// relationOperators are the keys a relation definition may use.
+var relationOperators = map[string]bool{
+	"this":             true,
+	"computed_userset": true,
+	"tuple_to_userset": true,
+	"union":            true,
+	"intersection":     true,
+	"exclusion":        true,
+}
+
+// operatorAliases maps the camelCase operator spellings used by OpenFGA
+// models, which the server also accepts, to their canonical names.
+var operatorAliases = map[string]string{
+	"computedUserset": "computed_userset",
+	"tupleToUserset":  "tuple_to_userset",
+}
+
+// canonicalOperator returns the canonical name of a relation operator key.
+func canonicalOperator(key string) string {
+	if op, ok := operatorAliases[key]; ok {
+		return op
+	}
+	return key
+}
+
+// computedUserset returns the computed_userset field of m under either of
+// its spellings.
+func computedUserset(m map[string]any) (any, bool) {
+	if v, ok := m["computed_userset"]; ok {
+		return v, true
+	}
+	v, ok := m["computedUserset"]
+	return v, ok
+}
+
+// setChildren returns the operands of a union or intersection, given either
+// as a list or, as in OpenFGA models, as {"child": [...]}.
+func setChildren(body any) ([]any, bool) {
+	if m, ok := body.(map[string]any); ok {
+		body = m["child"]
+	}
+	children, ok := body.([]any)
+	return children, ok
+}
+
+// ValidateModelSchema checks an authorization model in the JSON form
+// accepted by WriteModel, as produced by ParseModelDSL or in OpenFGA's
+// camelCase and {"child": [...]} forms, and returns a
+// human-readable description of each issue found, or nil if there are none.
+// It reports types and relations that are referenced but not defined,
+// unknown relation operators, and relations that only refer to each other
+// in a cycle and so can never grant access. The server may still reject a
+// model that passes.
+func ValidateModelSchema(schema map[string]any) []string {
+	defs, ok := schema["type_definitions"].([]any)
+	if !ok {
+		return []string{"type_definitions is missing or not a list"}
+	}
+	v := &modelValidator{types: make(map[string]map[string]any)}
+	var order []string
+	for i, d := range defs {
+		def, _ := d.(map[string]any)
+		name, _ := def["type"].(string)
+		if name == "" {
+			v.addf("type definition %d has no type name", i)
+			continue
+		}
+		if _, dup := v.types[name]; dup {
+			v.addf("type %q is defined twice", name)
+			continue
+		}
+		relations, _ := def["relations"].(map[string]any)
+		if relations == nil {
+			relations = map[string]any{}
+		}
+		v.types[name] = relations
+		order = append(order, name)
+	}
+	for _, typ := range order {
+		relations := v.types[typ]
+		for _, rel := range sortedKeys(relations) {
+			def, ok := relations[rel].(map[string]any)
+			if !ok {
+				v.addf("type %q, relation %q: definition is not an object", typ, rel)
+				continue
+			}
+			v.checkDef(typ, rel, def)
+		}
+		v.checkAliasCycles(typ, relations)
+	}
+	return v.issues
+}
+
+// modelValidator collects the issues found by ValidateModelSchema.
+type modelValidator struct {
+	types  map[string]map[string]any
+	issues []string
+}
+
+func (v *modelValidator) addf(format string, args ...any) {
+	v.issues = append(v.issues, fmt.Sprintf(format, args...))
+}
+
+func (v *modelValidator) hasRelation(typ, rel string) bool {
+	_, ok := v.types[typ][rel]
+	return ok
+}
+
+// checkDef checks one relation definition node, recursing into rewrites.
+func (v *modelValidator) checkDef(typ, rel string, def map[string]any) {
+	where := fmt.Sprintf("type %q, relation %q", typ, rel)
+	for _, key := range sortedKeys(def) {
+		op := canonicalOperator(key)
+		if !relationOperators[op] {
+			v.addf("%s: unknown operator %q", where, key)
+			continue
+		}
+		body := def[key]
+		switch op {
+		case "this":
+			for _, ref := range directTypes(def) {
+				v.checkTypeRef(where, ref)
+			}
+		case "computed_userset":
+			target := relationName(body)
+			if !v.hasRelation(typ, target) {
+				v.addf("%s: undefined relation %q", where, target)
+			}
+		case "tuple_to_userset":
+			ttu, _ := body.(map[string]any)
+			tupleset := relationName(ttu["tupleset"])
+			cu, _ := computedUserset(ttu)
+			computed := relationName(cu)
+			if !v.hasRelation(typ, tupleset) {
+				v.addf("%s: undefined relation %q", where, tupleset)
+				continue
+			}
+			parents := directTypes(v.types[typ][tupleset])
+			found := len(parents) == 0
+			for _, p := range parents {
+				parent, _, _ := strings.Cut(strings.TrimSuffix(p, ":*"), "#")
+				found = found || v.hasRelation(parent, computed)
+			}
+			if !found {
+				v.addf("%s: relation %q is not defined on any type assignable to %q", where, computed, tupleset)
+			}
+		case "union", "intersection":
+			children, ok := setChildren(body)
+			if !ok {
+				v.addf("%s: %s is not a list", where, op)
+				continue
+			}
+			for _, c := range children {
+				if child, ok := c.(map[string]any); ok {
+					v.checkDef(typ, rel, child)
+				} else {
+					v.addf("%s: %s entry is not an object", where, op)
+				}
+			}
+		case "exclusion":
+			ex, _ := body.(map[string]any)
+			for _, part := range []string{"base", "subtract"} {
+				if child, ok := ex[part].(map[string]any); ok {
+					v.checkDef(typ, rel, child)
+				} else {
+					v.addf("%s: exclusion %s is missing", where, part)
+				}
+			}
+		}
+	}
+}
+
+// checkTypeRef checks a directly assignable type: "user", "user:*" or
+// "group#member".
+func (v *modelValidator) checkTypeRef(where, ref string) {
+	typ, rel, hasRel := strings.Cut(strings.TrimSuffix(ref, ":*"), "#")
+	if _, ok := v.types[typ]; !ok {
+		v.addf("%s: undefined type %q", where, typ)
+		return
+	}
+	if hasRel && !v.hasRelation(typ, rel) {
+		v.addf("%s: undefined relation %q on type %q", where, rel, typ)
+	}
+}
+
+// checkAliasCycles reports relations of a type that are each defined as
+// just another relation of the same type, in a cycle.
+func (v *modelValidator) checkAliasCycles(typ string, relations map[string]any) {
+	alias := make(map[string]string)
+	for rel, d := range relations {
+		def, _ := d.(map[string]any)
+		if cu, ok := computedUserset(def); ok && len(def) == 1 {
+			alias[rel] = relationName(cu)
+		}
+	}
+	reported := make(map[string]bool)
+	for _, start := range sortedKeys(relations) {
+		var path []string
+		onPath := make(map[string]bool)
+		for rel := start; rel != "" && !reported[rel]; rel = alias[rel] {
+			if onPath[rel] {
+				cycle := path[slices.Index(path, rel):]
+				for _, r := range cycle {
+					reported[r] = true
+				}
+				v.addf("type %q: relations %s -> %s form a cycle", typ, strings.Join(cycle, " -> "), rel)
+				break
+			}
+			onPath[rel] = true
+			path = append(path, rel)
+		}
+		for _, r := range path {
+			reported[r] = true
+		}
+	}
+}
+
+// directTypes returns the directly assignable types of a relation
+// definition's "this" node, or nil if it has none.
+func directTypes(def any) []string {
+	m, _ := def.(map[string]any)
+	this, _ := m["this"].(map[string]any)
+	list, _ := this["types"].([]any)
+	var types []string
+	for _, t := range list {
+		if s, ok := t.(string); ok {
+			types = append(types, s)
+		}
+	}
+	return types
+}
+
+// relationName returns the "relation" field of a userset reference.
+func relationName(v any) string {
+	m, _ := v.(map[string]any)
+	name, _ := m["relation"].(string)
+	return name
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
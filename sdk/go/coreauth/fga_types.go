@@ -1,5 +1,11 @@
 package coreauth
 
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
 // CreateTupleRequest represents a request to create a relationship tuple.
 type CreateTupleRequest struct {
 	TenantID        string  `json:"tenant_id"`
@@ -11,6 +17,30 @@ type CreateTupleRequest struct {
 	SubjectRelation *string `json:"subject_relation,omitempty"`
 }
 
+// String returns the tuple's canonical form,
+// "namespace:object_id#relation@subject_type:subject_id", with
+// "#subject_relation" appended for a userset subject. Two tuples are the
+// same relationship exactly when their canonical forms are equal; the
+// tenant is not part of it.
+func (t CreateTupleRequest) String() string {
+	s := t.Namespace + ":" + t.ObjectID + "#" + t.Relation + "@" + t.SubjectType + ":" + t.SubjectID
+	if t.SubjectRelation != nil && *t.SubjectRelation != "" {
+		s += "#" + *t.SubjectRelation
+	}
+	return s
+}
+
+func (t CreateTupleRequest) toStoreTuple() StoreTuple {
+	return StoreTuple{
+		ObjectType:      t.Namespace,
+		ObjectID:        t.ObjectID,
+		Relation:        t.Relation,
+		SubjectType:     t.SubjectType,
+		SubjectID:       t.SubjectID,
+		SubjectRelation: t.SubjectRelation,
+	}
+}
+
 // QueryTuplesRequest represents a request to query relationship tuples.
 type QueryTuplesRequest struct {
 	TenantID    string  `json:"tenant_id"`
@@ -21,6 +51,26 @@ type QueryTuplesRequest struct {
 	SubjectID   *string `json:"subject_id,omitempty"`
 }
 
+// params encodes the filter as query parameters, omitting unset fields.
+func (r QueryTuplesRequest) params() map[string]string {
+	p := map[string]string{}
+	if r.TenantID != "" {
+		p["tenant_id"] = r.TenantID
+	}
+	for k, v := range map[string]*string{
+		"namespace":    r.Namespace,
+		"object_id":    r.ObjectID,
+		"relation":     r.Relation,
+		"subject_type": r.SubjectType,
+		"subject_id":   r.SubjectID,
+	} {
+		if v != nil {
+			p[k] = *v
+		}
+	}
+	return p
+}
+
 // RelationTuple represents a stored relationship tuple.
 type RelationTuple struct {
 	ID              string  `json:"id"`
@@ -34,6 +84,11 @@ type RelationTuple struct {
 	CreatedAt       *string `json:"created_at,omitempty"`
 }
 
+// CreatedAtTime returns CreatedAt parsed as a time, or the zero time if unset.
+func (t RelationTuple) CreatedAtTime() time.Time {
+	return parseTimestamp(t.CreatedAt)
+}
+
 // CheckRequest represents a request to check a permission.
 type CheckRequest struct {
 	TenantID    string         `json:"tenant_id"`
@@ -51,6 +106,51 @@ type CheckResponse struct {
 	Reason  *string `json:"reason,omitempty"`
 }
 
+// storeCheckRequest is a CheckRequest as the store-scoped check endpoints
+// expect it: the store replaces the tenant, and the namespace is called
+// object_type.
+type storeCheckRequest struct {
+	SubjectType string         `json:"subject_type"`
+	SubjectID   string         `json:"subject_id"`
+	Relation    string         `json:"relation"`
+	ObjectType  string         `json:"object_type"`
+	ObjectID    string         `json:"object_id"`
+	Context     map[string]any `json:"context,omitempty"`
+}
+
+func (r CheckRequest) toStoreCheck() storeCheckRequest {
+	return storeCheckRequest{
+		SubjectType: r.SubjectType,
+		SubjectID:   r.SubjectID,
+		Relation:    r.Relation,
+		ObjectType:  r.Namespace,
+		ObjectID:    r.ObjectID,
+		Context:     r.Context,
+	}
+}
+
+// ListObjectsRequest asks which objects of type Namespace the subject has
+// Relation on.
+type ListObjectsRequest struct {
+	TenantID    string         `json:"tenant_id,omitempty"`
+	SubjectType string         `json:"subject_type"`
+	SubjectID   string         `json:"subject_id"`
+	Relation    string         `json:"relation"`
+	Namespace   string         `json:"object_type"`
+	Context     map[string]any `json:"context,omitempty"`
+}
+
+// ListSubjectsRequest asks which subjects of type SubjectType have Relation
+// on the object.
+type ListSubjectsRequest struct {
+	TenantID    string         `json:"tenant_id,omitempty"`
+	Namespace   string         `json:"object_type"`
+	ObjectID    string         `json:"object_id"`
+	Relation    string         `json:"relation"`
+	SubjectType string         `json:"subject_type"`
+	Context     map[string]any `json:"context,omitempty"`
+}
+
 // ExpandResponse represents the result of expanding a relation.
 type ExpandResponse struct {
 	Tree map[string]any `json:"tree"`
@@ -81,15 +181,18 @@ type FgaStore struct {
 
 // CreateStoreRequest represents a request to create an FGA store.
 type CreateStoreRequest struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
+	Name        string         `json:"name"`
+	Description *string        `json:"description,omitempty"`
+	Settings    map[string]any `json:"settings,omitempty"`
 }
 
-// UpdateStoreRequest represents a request to update an FGA store.
+// UpdateStoreRequest represents a request to update an FGA store. Nil fields
+// are left unchanged.
 type UpdateStoreRequest struct {
-	Name        *string `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
-	IsActive    *bool   `json:"is_active,omitempty"`
+	Name        *string        `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	IsActive    *bool          `json:"is_active,omitempty"`
+	Settings    map[string]any `json:"settings,omitempty"`
 }
 
 // WriteModelRequest represents a request to write an authorization model to an FGA store.
@@ -134,6 +237,30 @@ type FgaStoreApiKey struct {
 	UpdatedAt          *string  `json:"updated_at,omitempty"`
 }
 
+// ExpiresAtTime returns the parsed expiry time, or the zero time if the key does not expire.
+func (k FgaStoreApiKey) ExpiresAtTime() time.Time {
+	return parseTimestamp(k.ExpiresAt)
+}
+
+// KeysExpiringWithin returns the active keys that expire within d from now,
+// including those that have already expired, soonest first. Keys without an
+// expiry are never returned. It suits a rotation job that warns before store
+// API keys lapse.
+func KeysExpiringWithin(keys []FgaStoreApiKey, d time.Duration) []FgaStoreApiKey {
+	deadline := time.Now().Add(d)
+	var expiring []FgaStoreApiKey
+	for _, k := range keys {
+		exp := k.ExpiresAtTime()
+		if k.IsActive && !exp.IsZero() && exp.Before(deadline) {
+			expiring = append(expiring, k)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresAtTime().Before(expiring[j].ExpiresAtTime())
+	})
+	return expiring
+}
+
 // FgaStoreApiKeyWithSecret represents an API key with its secret exposed (returned only on creation).
 type FgaStoreApiKeyWithSecret struct {
 	FgaStoreApiKey
@@ -145,3 +272,112 @@ type WriteTuplesRequest struct {
 	Writes  []map[string]any `json:"writes,omitempty"`
 	Deletes []map[string]any `json:"deletes,omitempty"`
 }
+
+// StoreTuple represents a relationship tuple as read from or written to a store.
+type StoreTuple struct {
+	ObjectType      string  `json:"object_type"`
+	ObjectID        string  `json:"object_id"`
+	Relation        string  `json:"relation"`
+	SubjectType     string  `json:"subject_type"`
+	SubjectID       string  `json:"subject_id"`
+	SubjectRelation *string `json:"subject_relation,omitempty"`
+}
+
+func (t RelationTuple) toStoreTuple() StoreTuple {
+	return StoreTuple{
+		ObjectType:      t.Namespace,
+		ObjectID:        t.ObjectID,
+		Relation:        t.Relation,
+		SubjectType:     t.SubjectType,
+		SubjectID:       t.SubjectID,
+		SubjectRelation: t.SubjectRelation,
+	}
+}
+
+// WriteTuplesResponse represents the result of a store tuple write.
+type WriteTuplesResponse struct {
+	Written int `json:"written"`
+	Deleted int `json:"deleted"`
+}
+
+// BatchResult summarizes a chunked tuple operation such as an import.
+type BatchResult struct {
+	Written int          `json:"written"`
+	Deleted int          `json:"deleted"`
+	Skipped int          `json:"skipped"`
+	Errors  []BatchError `json:"-"`
+}
+
+// BatchError describes an input line or chunk that could not be processed.
+// Line is 1-based and zero for chunk-level failures; Chunk is -1 for
+// failures that happened before the tuple was assigned to a chunk.
+type BatchError struct {
+	Line  int
+	Chunk int
+	Err   error
+}
+
+func (e BatchError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("chunk %d: %v", e.Chunk, e.Err)
+}
+
+func (e BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchProgressFunc is called after each chunk with the running totals.
+type BatchProgressFunc func(written, skipped int)
+
+// BootstrapRequest describes a store to create with FgaService.Bootstrap.
+// Exactly one of Schema and ModelDSL should be set.
+type BootstrapRequest struct {
+	Name        string
+	Description *string
+	// Schema is the authorization model in the JSON form accepted by
+	// WriteModel.
+	Schema map[string]any
+	// ModelDSL is the authorization model in the OpenFGA DSL; it is
+	// converted with ParseModelDSL.
+	ModelDSL  string
+	Tuples    []StoreTuple
+	CreatedBy *string
+}
+
+// BootstrapResult describes a store created by FgaService.Bootstrap.
+type BootstrapResult struct {
+	StoreID       string
+	ModelID       string
+	ModelVersion  int
+	TuplesWritten int
+}
+
+// BootstrapError reports the step at which FgaService.Bootstrap failed and
+// whether the partially created store was removed.
+type BootstrapError struct {
+	// Step is one of "create_store", "write_model", or "write_tuples".
+	Step    string
+	StoreID string
+	Err     error
+	// RolledBack is true if the store was deleted after the failure.
+	RolledBack bool
+	// RollbackErr is the error from deleting the store, if that failed.
+	RollbackErr error
+}
+
+func (e *BootstrapError) Error() string {
+	msg := fmt.Sprintf("bootstrap failed at %s: %v", e.Step, e.Err)
+	switch {
+	case e.RolledBack:
+		msg += fmt.Sprintf(" (store %s rolled back)", e.StoreID)
+	case e.RollbackErr != nil:
+		msg += fmt.Sprintf(" (rollback of store %s failed: %v)", e.StoreID, e.RollbackErr)
+	}
+	return msg
+}
+
+func (e *BootstrapError) Unwrap() error {
+	return e.Err
+}
@@ -1,9 +1,11 @@
 package coreauth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 // GroupsService provides group management, membership, role assignment, and invitation operations.
@@ -18,21 +20,45 @@ func (s *GroupsService) Create(ctx context.Context, tenantID string, data map[st
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/groups", tenantID), data)
 }
 
+// CreateInto is like Create but decodes the response into out.
+func (s *GroupsService) CreateInto(ctx context.Context, tenantID string, data map[string]any, out any) error {
+	raw, err := s.Create(ctx, tenantID, data)
+	return decodeResult(raw, err, out)
+}
+
 // List returns all groups within a tenant.
 func (s *GroupsService) List(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/groups", tenantID), nil)
 }
 
+// ListInto is like List but decodes the response into out.
+func (s *GroupsService) ListInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.List(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
 // Get retrieves a specific group by ID.
 func (s *GroupsService) Get(ctx context.Context, tenantID, groupID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s", tenantID, groupID), nil)
 }
 
+// GetInto is like Get but decodes the response into out.
+func (s *GroupsService) GetInto(ctx context.Context, tenantID, groupID string, out any) error {
+	raw, err := s.Get(ctx, tenantID, groupID)
+	return decodeResult(raw, err, out)
+}
+
 // Update modifies an existing group.
 func (s *GroupsService) Update(ctx context.Context, tenantID, groupID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s", tenantID, groupID), data)
 }
 
+// UpdateInto is like Update but decodes the response into out.
+func (s *GroupsService) UpdateInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error {
+	raw, err := s.Update(ctx, tenantID, groupID, data)
+	return decodeResult(raw, err, out)
+}
+
 // Delete removes a group.
 func (s *GroupsService) Delete(ctx context.Context, tenantID, groupID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s", tenantID, groupID), nil)
@@ -46,16 +72,34 @@ func (s *GroupsService) AddMember(ctx context.Context, tenantID, groupID string,
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/members", tenantID, groupID), data)
 }
 
+// AddMemberInto is like AddMember but decodes the response into out.
+func (s *GroupsService) AddMemberInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error {
+	raw, err := s.AddMember(ctx, tenantID, groupID, data)
+	return decodeResult(raw, err, out)
+}
+
 // ListMembers returns all members of a group.
 func (s *GroupsService) ListMembers(ctx context.Context, tenantID, groupID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/members", tenantID, groupID), nil)
 }
 
+// ListMembersInto is like ListMembers but decodes the response into out.
+func (s *GroupsService) ListMembersInto(ctx context.Context, tenantID, groupID string, out any) error {
+	raw, err := s.ListMembers(ctx, tenantID, groupID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateMember updates a member's attributes within a group.
 func (s *GroupsService) UpdateMember(ctx context.Context, tenantID, groupID, userID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/members/%s", tenantID, groupID, userID), data)
 }
 
+// UpdateMemberInto is like UpdateMember but decodes the response into out.
+func (s *GroupsService) UpdateMemberInto(ctx context.Context, tenantID, groupID, userID string, data map[string]any, out any) error {
+	raw, err := s.UpdateMember(ctx, tenantID, groupID, userID, data)
+	return decodeResult(raw, err, out)
+}
+
 // RemoveMember removes a user from a group.
 func (s *GroupsService) RemoveMember(ctx context.Context, tenantID, groupID, userID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/members/%s", tenantID, groupID, userID), nil)
@@ -69,11 +113,23 @@ func (s *GroupsService) AssignRole(ctx context.Context, tenantID, groupID string
 	return s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/roles", tenantID, groupID), data)
 }
 
+// AssignRoleInto is like AssignRole but decodes the response into out.
+func (s *GroupsService) AssignRoleInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error {
+	raw, err := s.AssignRole(ctx, tenantID, groupID, data)
+	return decodeResult(raw, err, out)
+}
+
 // ListRoles returns all roles assigned to a group.
 func (s *GroupsService) ListRoles(ctx context.Context, tenantID, groupID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/roles", tenantID, groupID), nil)
 }
 
+// ListRolesInto is like ListRoles but decodes the response into out.
+func (s *GroupsService) ListRolesInto(ctx context.Context, tenantID, groupID string, out any) error {
+	raw, err := s.ListRoles(ctx, tenantID, groupID)
+	return decodeResult(raw, err, out)
+}
+
 // RemoveRole removes a role from a group.
 func (s *GroupsService) RemoveRole(ctx context.Context, tenantID, groupID, roleID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/roles/%s", tenantID, groupID, roleID), nil)
@@ -87,6 +143,57 @@ func (s *GroupsService) GetUserGroups(ctx context.Context, tenantID, userID stri
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/users/%s/groups", tenantID, userID), nil)
 }
 
+// GetUserGroupsInto is like GetUserGroups but decodes the response into out.
+func (s *GroupsService) GetUserGroupsInto(ctx context.Context, tenantID, userID string, out any) error {
+	raw, err := s.GetUserGroups(ctx, tenantID, userID)
+	return decodeResult(raw, err, out)
+}
+
+// SyncMembers makes a group's membership exactly userIDs, adding missing
+// members and removing extra ones. Individual failures are reported in the
+// result's Errors; the returned error is non-nil only if the current members
+// could not be listed or ctx was cancelled.
+func (s *GroupsService) SyncMembers(ctx context.Context, tenantID, groupID string, userIDs []string) (*ReconcileResult, error) {
+	var members []GroupMember
+	if err := s.ListMembersInto(ctx, tenantID, groupID, &members); err != nil {
+		return nil, err
+	}
+	current := make([]string, len(members))
+	for i, m := range members {
+		current[i] = m.UserID
+	}
+	return reconcile(ctx, current, userIDs,
+		func(userID string) error {
+			_, err := s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/members", tenantID, groupID), AddGroupMemberRequest{UserID: userID})
+			return err
+		},
+		func(userID string) error {
+			return s.RemoveMember(ctx, tenantID, groupID, userID)
+		})
+}
+
+// SyncRoles makes the roles assigned to a group exactly roleIDs, assigning
+// missing roles and removing extra ones. Failures are reported as in
+// SyncMembers.
+func (s *GroupsService) SyncRoles(ctx context.Context, tenantID, groupID string, roleIDs []string) (*ReconcileResult, error) {
+	var roles []GroupRole
+	if err := s.ListRolesInto(ctx, tenantID, groupID, &roles); err != nil {
+		return nil, err
+	}
+	current := make([]string, len(roles))
+	for i, r := range roles {
+		current[i] = r.RoleID
+	}
+	return reconcile(ctx, current, roleIDs,
+		func(roleID string) error {
+			_, err := s.http.post(ctx, fmt.Sprintf("/api/tenants/%s/groups/%s/roles", tenantID, groupID), AssignGroupRoleRequest{RoleID: roleID})
+			return err
+		},
+		func(roleID string) error {
+			return s.RemoveRole(ctx, tenantID, groupID, roleID)
+		})
+}
+
 // --- Invitations ---
 
 // CreateInvitation creates a new invitation to join an organization.
@@ -94,11 +201,60 @@ func (s *GroupsService) CreateInvitation(ctx context.Context, orgID string, data
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/invitations", orgID), data)
 }
 
+// CreateInvitationInto is like CreateInvitation but decodes the response into out.
+func (s *GroupsService) CreateInvitationInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.CreateInvitation(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // ListInvitations returns all invitations for an organization.
 func (s *GroupsService) ListInvitations(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/invitations", orgID), nil)
 }
 
+// ListInvitationsInto is like ListInvitations but decodes the response into out.
+func (s *GroupsService) ListInvitationsInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.ListInvitations(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
+// InvitationsPaginator returns a Paginator over an organization's
+// invitations, fetching pageSize invitations per request. Servers that
+// return every invitation in a single array yield a single page.
+func (s *GroupsService) InvitationsPaginator(orgID string, pageSize int) *Paginator[InvitationResponse] {
+	return NewPaginator(offsetPageFunc(pageSize, func(ctx context.Context, offset, limit int) ([]InvitationResponse, int, error) {
+		raw, err := s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/invitations", orgID), map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		trimmed := bytes.TrimSpace(raw)
+		if len(trimmed) == 0 || trimmed[0] == '[' {
+			var invitations []InvitationResponse
+			if err := decodeJSON(trimmed, &invitations); err != nil {
+				return nil, 0, err
+			}
+			return invitations, offset + len(invitations), nil
+		}
+		var page struct {
+			Invitations []InvitationResponse `json:"invitations"`
+			Total       int                  `json:"total"`
+		}
+		if err := decodeJSON(trimmed, &page); err != nil {
+			return nil, 0, err
+		}
+		return page.Invitations, page.Total, nil
+	}))
+}
+
+// ListAllInvitations returns every invitation for an organization, following
+// pagination.
+func (s *GroupsService) ListAllInvitations(ctx context.Context, orgID string) ([]InvitationResponse, error) {
+	return s.InvitationsPaginator(orgID, defaultPageSize).All(ctx)
+}
+
 // RevokeInvitation revokes an outstanding invitation.
 func (s *GroupsService) RevokeInvitation(ctx context.Context, orgID, invitationID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/invitations/%s", orgID, invitationID), nil)
@@ -110,12 +266,30 @@ func (s *GroupsService) ResendInvitation(ctx context.Context, orgID, invitationI
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/invitations/%s/resend", orgID, invitationID), nil)
 }
 
+// ResendInvitationInto is like ResendInvitation but decodes the response into out.
+func (s *GroupsService) ResendInvitationInto(ctx context.Context, orgID, invitationID string, out any) error {
+	raw, err := s.ResendInvitation(ctx, orgID, invitationID)
+	return decodeResult(raw, err, out)
+}
+
 // VerifyInvitation validates an invitation token without accepting it.
 func (s *GroupsService) VerifyInvitation(ctx context.Context, token string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/invitations/verify", map[string]string{"token": token})
 }
 
+// VerifyInvitationInto is like VerifyInvitation but decodes the response into out.
+func (s *GroupsService) VerifyInvitationInto(ctx context.Context, token string, out any) error {
+	raw, err := s.VerifyInvitation(ctx, token)
+	return decodeResult(raw, err, out)
+}
+
 // AcceptInvitation accepts an invitation using its token.
 func (s *GroupsService) AcceptInvitation(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/invitations/accept", data)
 }
+
+// AcceptInvitationInto is like AcceptInvitation but decodes the response into out.
+func (s *GroupsService) AcceptInvitationInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.AcceptInvitation(ctx, data)
+	return decodeResult(raw, err, out)
+}
@@ -2,108 +2,365 @@ package coreauth
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type httpClient struct {
-	baseURL    string
-	token      string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	dryRun      func(method, url string, body []byte)
+	middlewares []Middleware
+	mutators    []RequestMutator
+	logger      Logger
+	cache       responseCache
+	userAgent   string
+	newID       func() string
+
+	stripNulls       bool
+	conflictAttempts int
+	retryAttempts    int
+	retryDelay       time.Duration
+	retrySchedule    *BackoffSchedule
+	retryScheduleMu  sync.Mutex
+	featureFallback  bool
+	fallbackWarned   sync.Map
+	structuredLogger StructuredLogger
+	autoStoreToken   bool
+	ssoRedirectURI   string
+	validateModels   bool
+	authEvents       func(AuthEvent)
+
+	// mu guards the token state below, which is shared by concurrent calls.
+	mu           sync.Mutex
+	token        string
+	refreshToken string
+	refreshing   *refreshCall
+	refreshErr   error
 }
 
 func newHTTPClient(baseURL string, hc *http.Client) *httpClient {
 	return &httpClient{
 		baseURL:    strings.TrimRight(baseURL, "/"),
 		httpClient: hc,
+		userAgent:  defaultUserAgent,
 	}
 }
 
 func (c *httpClient) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.token = token
+	c.refreshErr = nil
 }
 
 func (c *httpClient) clearToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.token = ""
+	c.refreshToken = ""
+	c.refreshErr = nil
 }
 
-func (c *httpClient) doRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (json.RawMessage, error) {
-	u := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, u, body)
-	if err != nil {
-		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to create request: %v", err)}
+func (c *httpClient) currentToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// tokenSubject returns the sub claim of the stored token, read without
+// verifying it, or "" if there is no token or it is not a JWT.
+func (c *httpClient) tokenSubject() string {
+	parts := strings.Split(c.currentToken(), ".")
+	if len(parts) != 3 {
+		return ""
 	}
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
+	var claims map[string]any
+	if decodeSegment(parts[1], &claims) != nil {
+		return ""
 	}
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	return stringClaim(claims, "sub")
+}
+
+// storeIssuedToken records a token issued by an auth call if the client was
+// created with WithAutoStoreToken.
+func (c *httpClient) storeIssuedToken(token string) {
+	if c.autoStoreToken && token != "" {
+		c.setToken(token)
+	}
+}
+
+func (c *httpClient) doRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (json.RawMessage, error) {
+	resp, err := c.do(ctx, method, path, body, contentType)
+	if err != nil {
+		return nil, err
 	}
+	return resp.Body, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// do is like doRequest but returns the response's status and headers along
+// with its body. For a non-2xx response it returns the Response alongside
+// the error.
+func (c *httpClient) do(ctx context.Context, method, path string, body io.Reader, contentType string) (*Response, error) {
+	if c.retryAttempts > 1 {
+		return c.doWithRetry(ctx, method, path, body, contentType)
+	}
+	resp, err := c.execute(ctx, method, path, body, contentType)
 	if err != nil {
-		return nil, &CoreAuthError{Message: fmt.Sprintf("request failed: %v", err)}
+		return nil, err
 	}
+	return readResponse(resp)
+}
+
+// readResponse reads and closes the body of resp. For a non-2xx response it
+// returns the API error alongside the Response.
+func readResponse(resp *http.Response) (*Response, error) {
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	body, err := decodedBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	respBody, err := io.ReadAll(body)
 	if err != nil {
-		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to read response: %v", err)}
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to read response: %v", err), Err: err}
 	}
 
-	if resp.StatusCode == 204 {
-		return nil, nil
+	r := &Response{StatusCode: resp.StatusCode, Header: resp.Header}
+	if len(respBody) > 0 && resp.StatusCode != 204 {
+		r.Body = json.RawMessage(respBody)
 	}
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		if len(respBody) == 0 {
-			return nil, nil
+		return r, nil
+	}
+	return r, responseError(resp, respBody)
+}
+
+// decodedBody returns the body of resp, decompressed if it carries a gzip
+// Content-Encoding. The transport only decodes gzip itself when it asked for
+// it, so a compressed body still arrives here when Accept-Encoding was set
+// explicitly, e.g. by a middleware or by download. Closing the returned
+// reader does not close resp.Body.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.NopCloser(resp.Body), nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to read gzip response: %v", err), Err: err}
+	}
+	return zr, nil
+}
+
+// execute builds a request and runs it through the pipeline, returning the
+// response with its body unread. The request counts as a first attempt
+// unless ctx already records a later one.
+func (c *httpClient) execute(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	if _, _, ok := AttemptInfo(ctx); !ok {
+		ctx = withAttempt(ctx, 1, time.Now())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to create request: %v", err), Err: err}
+	}
+	// Only bodied requests declare a Content-Type; some strict proxies
+	// reject one on a GET.
+	if contentType != "" && body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for k, v := range requestHeaders(ctx) {
+		req.Header[k] = v
+	}
+	c.setGeneratedIDs(req)
+
+	resp, err := c.pipeline().RoundTrip(req)
+	if err != nil {
+		// A middleware may return a response alongside its error; nobody
+		// else will close it.
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
 		}
-		return json.RawMessage(respBody), nil
+		return nil, &CoreAuthError{Message: fmt.Sprintf("request failed: %v", err), Err: err}
 	}
+	return resp, nil
+}
 
-	// Parse error
-	apiErr := &ApiError{StatusCode: resp.StatusCode}
+// responseError builds the error for a non-2xx response and its body: a
+// *MaintenanceError for a 503 flagged as maintenance, an *ApiError otherwise.
+func responseError(resp *http.Response, body []byte) error {
+	apiErr := &ApiError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	if req := resp.Request; req != nil {
+		apiErr.Method = req.Method
+		apiErr.Path = req.URL.Path
+	}
 	var errBody struct {
-		Error   string `json:"error"`
-		Message string `json:"message"`
+		Error       string          `json:"error"`
+		Message     string          `json:"message"`
+		Description string          `json:"error_description"`
+		RetryAfter  *float64        `json:"retry_after"`
+		Maintenance json.RawMessage `json:"maintenance"`
+	}
+	if json.Unmarshal(body, &errBody) != nil {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+	apiErr.ErrorCode = errBody.Error
+	apiErr.Message = errBody.Message
+	if apiErr.Message == "" {
+		// OAuth2 endpoints report errors as {error, error_description}.
+		apiErr.Message = errBody.Description
+	}
+	if apiErr.RetryAfter == 0 && errBody.RetryAfter != nil {
+		apiErr.RetryAfter = secondsToDuration(*errBody.RetryAfter)
+	}
+	if resp.StatusCode == http.StatusServiceUnavailable && (errBody.Error == CodeMaintenance || isMaintenanceFlag(errBody.Maintenance)) {
+		return &MaintenanceError{RetryAfter: apiErr.RetryAfter, Err: apiErr}
 	}
-	if json.Unmarshal(respBody, &errBody) == nil {
-		apiErr.ErrorCode = errBody.Error
-		apiErr.Message = errBody.Message
-	} else {
-		apiErr.Message = string(respBody)
+	return apiErr
+}
+
+// isMaintenanceFlag reports whether a "maintenance" body field is set,
+// either as true or as an object describing the maintenance window.
+func isMaintenanceFlag(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return bytes.Equal(trimmed, []byte("true")) || (len(trimmed) > 0 && trimmed[0] == '{')
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP date. It returns zero if the header is absent or invalid.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		return secondsToDuration(secs)
 	}
-	return nil, apiErr
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func secondsToDuration(secs float64) time.Duration {
+	if secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
 }
 
 func (c *httpClient) get(ctx context.Context, path string, params map[string]string) (json.RawMessage, error) {
-	if len(params) > 0 {
-		v := url.Values{}
-		for k, val := range params {
-			if val != "" {
-				v.Set(k, val)
+	return c.getValues(ctx, path, queryValues(params))
+}
+
+// queryValues converts query parameters to url.Values, dropping empty ones.
+func queryValues(params map[string]string) url.Values {
+	v := url.Values{}
+	for k, val := range params {
+		if val != "" {
+			v.Set(k, val)
+		}
+	}
+	return v
+}
+
+// getValues is like get but takes url.Values, so a key may be repeated
+// (e.g. event_types=a&event_types=b).
+func (c *httpClient) getValues(ctx context.Context, path string, params url.Values) (json.RawMessage, error) {
+	if encoded := params.Encode(); encoded != "" {
+		path = path + "?" + encoded
+	}
+	return c.doRequest(ctx, http.MethodGet, path, nil, "")
+}
+
+// download streams the body of a GET to w as it arrives, without buffering
+// it. The server may gzip the body, which is decompressed on the fly. A
+// failure partway through, including a truncated or corrupt gzip stream, is
+// returned after whatever was already written to w. Non-2xx responses fail
+// with the same errors as doRequest.
+func (c *httpClient) download(ctx context.Context, path string, params map[string]string, w io.Writer) error {
+	if encoded := queryValues(params).Encode(); encoded != "" {
+		path = path + "?" + encoded
+	}
+	ctx = withRequestHeader(ctx, "Accept-Encoding", "gzip")
+	resp, err := c.execute(withStreamRequest(ctx), http.MethodGet, path, nil, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, err := readResponse(resp)
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := decodedBody(resp)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if _, err := io.Copy(w, body); err != nil {
+		return &CoreAuthError{Message: fmt.Sprintf("download interrupted: %v", err), Err: err}
+	}
+	return nil
+}
+
+// encodeBody marshals a JSON request body, returning nil for a nil payload.
+func (c *httpClient) encodeBody(payload any) (io.Reader, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	if c.stripNulls {
+		payload = stripNullValues(payload)
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to marshal request: %v", err), Err: err}
+	}
+	return bytes.NewReader(b), nil
+}
+
+// stripNullValues returns a copy of v with nil entries removed from every
+// map[string]any it contains, including maps nested in maps and []any.
+// Other values, including structs, are returned as is.
+func stripNullValues(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if val != nil {
+				out[k] = stripNullValues(val)
 			}
 		}
-		if encoded := v.Encode(); encoded != "" {
-			path = path + "?" + encoded
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = stripNullValues(val)
 		}
+		return out
 	}
-	return c.doRequest(ctx, http.MethodGet, path, nil, "application/json")
+	return v
 }
 
 func (c *httpClient) post(ctx context.Context, path string, payload any) (json.RawMessage, error) {
-	var body io.Reader
-	if payload != nil {
-		b, err := json.Marshal(payload)
-		if err != nil {
-			return nil, &CoreAuthError{Message: fmt.Sprintf("failed to marshal request: %v", err)}
-		}
-		body = bytes.NewReader(b)
+	body, err := c.encodeBody(payload)
+	if err != nil {
+		return nil, err
 	}
 	return c.doRequest(ctx, http.MethodPost, path, body, "application/json")
 }
@@ -113,37 +370,25 @@ func (c *httpClient) postForm(ctx context.Context, path string, data url.Values)
 }
 
 func (c *httpClient) put(ctx context.Context, path string, payload any) (json.RawMessage, error) {
-	var body io.Reader
-	if payload != nil {
-		b, err := json.Marshal(payload)
-		if err != nil {
-			return nil, &CoreAuthError{Message: fmt.Sprintf("failed to marshal request: %v", err)}
-		}
-		body = bytes.NewReader(b)
+	body, err := c.encodeBody(payload)
+	if err != nil {
+		return nil, err
 	}
 	return c.doRequest(ctx, http.MethodPut, path, body, "application/json")
 }
 
 func (c *httpClient) patch(ctx context.Context, path string, payload any) (json.RawMessage, error) {
-	var body io.Reader
-	if payload != nil {
-		b, err := json.Marshal(payload)
-		if err != nil {
-			return nil, &CoreAuthError{Message: fmt.Sprintf("failed to marshal request: %v", err)}
-		}
-		body = bytes.NewReader(b)
+	body, err := c.encodeBody(payload)
+	if err != nil {
+		return nil, err
 	}
 	return c.doRequest(ctx, http.MethodPatch, path, body, "application/json")
 }
 
 func (c *httpClient) del(ctx context.Context, path string, payload any) (json.RawMessage, error) {
-	var body io.Reader
-	if payload != nil {
-		b, err := json.Marshal(payload)
-		if err != nil {
-			return nil, &CoreAuthError{Message: fmt.Sprintf("failed to marshal request: %v", err)}
-		}
-		body = bytes.NewReader(b)
+	body, err := c.encodeBody(payload)
+	if err != nil {
+		return nil, err
 	}
 	return c.doRequest(ctx, http.MethodDelete, path, body, "application/json")
 }
@@ -0,0 +1,191 @@
+package coreauth
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// checkGoroutines fails t at cleanup if goroutines started during the test
+// are still running, after giving them a moment to exit. Register it before
+// the test server so the server is closed first.
+func checkGoroutines(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if n := runtime.NumGoroutine(); n > before {
+			buf := make([]byte, 1<<20)
+			buf = buf[:runtime.Stack(buf, true)]
+			t.Errorf("%d goroutines leaked:\n%s", n-before, buf)
+		}
+	})
+}
+
+// newTestClient returns a client for srv with its own transport, whose idle
+// connections are closed when the test ends.
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+	tr := &http.Transport{}
+	t.Cleanup(tr.CloseIdleConnections)
+	return NewClient(srv.URL, append([]Option{WithHTTPClient(&http.Client{Transport: tr})}, opts...)...)
+}
+
+// newSlowServer returns a server that sends the response headers and part
+// of a body, then stalls until the client goes away.
+func newSlowServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"partial":`))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCancelMidResponse(t *testing.T) {
+	checkGoroutines(t)
+	c := newTestClient(t, newSlowServer(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Auth.GetProfile(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetProfile error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestCancelMidDownload(t *testing.T) {
+	checkGoroutines(t)
+	c := newTestClient(t, newSlowServer(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	var out strings.Builder
+	if err := c.Audit.ExportTo(ctx, time.Time{}, time.Time{}, &out); err == nil {
+		t.Fatal("ExportTo succeeded after cancellation")
+	}
+	if got := out.String(); got != `{"partial":` {
+		t.Errorf("ExportTo wrote %q before failing, want the partial body", got)
+	}
+}
+
+// notifyWriter closes notify on its first write.
+type notifyWriter struct {
+	strings.Builder
+	once   sync.Once
+	notify chan struct{}
+}
+
+func (w *notifyWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.notify) })
+	return w.Builder.Write(p)
+}
+
+func TestDownloadStreamsChunkedGzip(t *testing.T) {
+	checkGoroutines(t)
+	firstChunk := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`[{"id":"1"}`))
+		zw.Flush()
+		w.(http.Flusher).Flush()
+		// The rest is only sent once the client has written the first
+		// chunk, which it cannot do if it buffers the whole body.
+		select {
+		case <-firstChunk:
+		case <-r.Context().Done():
+			return
+		}
+		zw.Write([]byte(`,{"id":"2"}]`))
+		zw.Close()
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := &notifyWriter{notify: firstChunk}
+	if err := c.Audit.ExportTo(ctx, time.Time{}, time.Time{}, out); err != nil {
+		t.Fatalf("ExportTo: %v", err)
+	}
+	if got, want := out.String(), `[{"id":"1"},{"id":"2"}]`; got != want {
+		t.Errorf("ExportTo wrote %q, want %q", got, want)
+	}
+}
+
+func TestDownloadTruncatedGzip(t *testing.T) {
+	checkGoroutines(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`[{"id":"1"}`))
+		zw.Flush() // no Close, so the stream ends without its trailer
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	var out strings.Builder
+	err := c.Audit.ExportTo(context.Background(), time.Time{}, time.Time{}, &out)
+	if err == nil {
+		t.Fatal("ExportTo succeeded on a truncated gzip stream")
+	}
+	if got := out.String(); got != `[{"id":"1"}` {
+		t.Errorf("ExportTo wrote %q before failing, want the decompressed prefix", got)
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	checkGoroutines(t)
+	type headers struct{ accept, contentType string }
+	got := make(map[string]headers)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got[r.Method] = headers{r.Header.Get("Accept"), r.Header.Get("Content-Type")}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	ctx := context.Background()
+	if _, err := c.Auth.GetProfile(ctx); err != nil {
+		t.Fatalf("GetProfile: %v", err)
+	}
+	body := map[string]any{"name": "x"}
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+		if _, err := c.Do(ctx, method, "/api/x", body); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+	if _, err := c.Do(ctx, http.MethodDelete, "/api/x", nil); err != nil {
+		t.Fatalf("DELETE: %v", err)
+	}
+
+	want := map[string]headers{
+		http.MethodGet:    {"application/json", ""},
+		http.MethodPost:   {"application/json", "application/json"},
+		http.MethodPut:    {"application/json", "application/json"},
+		http.MethodPatch:  {"application/json", "application/json"},
+		http.MethodDelete: {"application/json", ""},
+	}
+	for method, w := range want {
+		if got[method] != w {
+			t.Errorf("%s sent Accept %q and Content-Type %q, want %q and %q", method, got[method].accept, got[method].contentType, w.accept, w.contentType)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package coreauth
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader carries a per-call ID the server can log, so a client
+	// request can be correlated with server-side logs and audit entries.
+	RequestIDHeader = "X-Request-ID"
+	// IdempotencyKeyHeader carries a per-call key sent with POST and PATCH
+	// calls, so a replayed call can be correlated with the original. The
+	// CoreAuth server currently ignores it and does not deduplicate replays.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// WithIDGenerator sets the function used for client-generated IDs: the
+// X-Request-ID sent with every call and the Idempotency-Key sent with POST
+// and PATCH calls. The default generates random version 4 UUIDs; tests can
+// inject a deterministic generator, and callers can align the IDs with their
+// own tracing scheme. A generator returning "" suppresses the header.
+func WithIDGenerator(gen func() string) Option {
+	return func(c *Client) {
+		c.http.newID = gen
+	}
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// setGeneratedIDs adds the correlation and idempotency headers to req unless
+// the caller already set them. They are set once per call, before the
+// pipeline, so retries of the call reuse the same IDs.
+func (c *httpClient) setGeneratedIDs(req *http.Request) {
+	gen := c.newID
+	if gen == nil {
+		gen = newUUID
+	}
+	if req.Header.Get(RequestIDHeader) == "" {
+		if id := gen(); id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+	}
+	if req.Method != http.MethodPost && req.Method != http.MethodPatch {
+		return
+	}
+	if req.Header.Get(IdempotencyKeyHeader) == "" {
+		if key := gen(); key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+	}
+}
+
+// withGeneratedIDs returns a copy of ctx carrying the headers
+// setGeneratedIDs would add to a request with the given method, so every
+// attempt of a retried call sends the same IDs.
+func (c *httpClient) withGeneratedIDs(ctx context.Context, method string) context.Context {
+	req := &http.Request{Method: method, Header: requestHeaders(ctx).Clone()}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	c.setGeneratedIDs(req)
+	return context.WithValue(ctx, headersContextKey, req.Header)
+}
@@ -0,0 +1,106 @@
+package coreauth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// idTokenLeeway is the clock skew VerifyIDToken tolerates when checking exp
+// and iat.
+const idTokenLeeway = time.Minute
+
+// idTokenStandardClaims are the claims decoded into IDTokenClaims fields;
+// every other claim goes to IDTokenClaims.Custom.
+var idTokenStandardClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "iat": true,
+	"auth_time": true, "nonce": true, "azp": true, "email": true,
+	"email_verified": true, "name": true,
+}
+
+// IDTokenClaims are the claims of an ID token verified by
+// OAuth2Service.VerifyIDToken.
+type IDTokenClaims struct {
+	Issuer          string
+	Subject         string
+	Audience        []string
+	ExpiresAt       time.Time
+	IssuedAt        time.Time
+	AuthTime        time.Time
+	Nonce           string
+	AuthorizedParty string
+	Email           string
+	EmailVerified   bool
+	Name            string
+	// Custom holds every claim not decoded into a field above.
+	Custom map[string]any
+}
+
+// VerifyIDToken verifies an OpenID Connect ID token issued to the client
+// expectedAudience. The signature, RS256, RS384, RS512 or ES256, is checked
+// against the key named by the token's kid, looked up in a JWKSCache the
+// service keeps. The iss claim must equal the issuer in the discovery
+// document, aud must include expectedAudience, and exp and iat are checked
+// allowing one minute of clock skew. The caller should still compare Nonce
+// with the nonce it sent.
+//
+// A malformed token returns an error matching ErrInvalidToken; a signature
+// that does not verify, one matching ErrInvalidSignature as well; and a
+// claim that fails validation, a *ClaimError matching ErrInvalidClaims, and
+// ErrTokenExpired if the token has expired.
+func (s *OAuth2Service) VerifyIDToken(ctx context.Context, idToken string, expectedAudience string) (*IDTokenClaims, error) {
+	claims, err := s.verifySignedToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	var discovery OidcDiscovery
+	if err := s.DiscoveryInto(ctx, &discovery); err != nil {
+		return nil, err
+	}
+	return checkIDTokenClaims(claims, discovery.Issuer, expectedAudience, time.Now())
+}
+
+// checkIDTokenClaims validates verified ID token claims and builds the
+// result.
+func checkIDTokenClaims(claims map[string]any, issuer, audience string, now time.Time) (*IDTokenClaims, error) {
+	t := &IDTokenClaims{
+		Issuer:          stringClaim(claims, "iss"),
+		Subject:         stringClaim(claims, "sub"),
+		Audience:        audienceClaim(claims["aud"]),
+		ExpiresAt:       timeClaim(claims, "exp"),
+		IssuedAt:        timeClaim(claims, "iat"),
+		AuthTime:        timeClaim(claims, "auth_time"),
+		Nonce:           stringClaim(claims, "nonce"),
+		AuthorizedParty: stringClaim(claims, "azp"),
+		Email:           stringClaim(claims, "email"),
+		Name:            stringClaim(claims, "name"),
+	}
+	t.EmailVerified, _ = claims["email_verified"].(bool)
+	switch {
+	case t.Issuer != issuer:
+		return nil, &ClaimError{Claim: "iss", Reason: fmt.Sprintf("%q does not match the issuer %q", t.Issuer, issuer)}
+	case t.Subject == "":
+		return nil, &ClaimError{Claim: "sub", Reason: "missing"}
+	case !containsString(t.Audience, audience):
+		return nil, &ClaimError{Claim: "aud", Reason: fmt.Sprintf("does not include %q", audience)}
+	case len(t.Audience) > 1 && t.AuthorizedParty != "" && t.AuthorizedParty != audience:
+		return nil, &ClaimError{Claim: "azp", Reason: fmt.Sprintf("%q is not %q", t.AuthorizedParty, audience)}
+	case t.ExpiresAt.IsZero():
+		return nil, &ClaimError{Claim: "exp", Reason: "missing"}
+	case now.After(t.ExpiresAt.Add(idTokenLeeway)):
+		return nil, &ClaimError{Claim: "exp", Reason: "expired at " + t.ExpiresAt.Format(time.RFC3339), Err: ErrTokenExpired}
+	case t.IssuedAt.IsZero():
+		return nil, &ClaimError{Claim: "iat", Reason: "missing"}
+	case t.IssuedAt.After(now.Add(idTokenLeeway)):
+		return nil, &ClaimError{Claim: "iat", Reason: "issued in the future at " + t.IssuedAt.Format(time.RFC3339)}
+	}
+	for name, v := range claims {
+		if !idTokenStandardClaims[name] {
+			if t.Custom == nil {
+				t.Custom = make(map[string]any)
+			}
+			t.Custom[name] = v
+		}
+	}
+	return t, nil
+}
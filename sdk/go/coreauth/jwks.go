@@ -0,0 +1,152 @@
+package coreauth
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// defaultJWKSCacheTTL is the JWKSCache TTL used when none is given.
+const defaultJWKSCacheTTL = 5 * time.Minute
+
+// jwksMinRefreshInterval is the least time between two fetches of the key
+// set caused by unknown key IDs, so tokens with made-up kids cannot turn
+// every verification into a request.
+const jwksMinRefreshInterval = 10 * time.Second
+
+// JWKSCache holds the server's JSON Web Key Set, from
+// /.well-known/jwks.json, indexed by key ID, for verifying tokens on a hot
+// path without fetching the set each time. The set is fetched again once
+// the TTL has passed, and also when a key ID is not found, so keys the
+// server rotates in are picked up right away; such misses refetch at most
+// once every 10 seconds. Concurrent lookups that need a fetch share a single
+// request. A JWKSCache is safe for concurrent use.
+type JWKSCache struct {
+	oauth *OAuth2Service
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	keys     map[string]map[string]any
+	only     map[string]any
+	fetched  time.Time
+	fetching *jwksFetch
+}
+
+// jwksFetch is an in-flight key set fetch shared by concurrent lookups.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewJWKSCache returns a JWKSCache that fetches the key set from the same
+// endpoint as c.OAuth2.JWKS and keeps it for ttl, or five minutes if ttl is
+// not positive. Its fetches always reach the server, even when
+// WithMetadataCacheTTL would serve JWKS from the client's cache. Nothing is
+// fetched until the first lookup.
+func NewJWKSCache(c *Client, ttl time.Duration) *JWKSCache {
+	return newJWKSCache(c.OAuth2, ttl)
+}
+
+func newJWKSCache(oauth *OAuth2Service, ttl time.Duration) *JWKSCache {
+	if ttl <= 0 {
+		ttl = defaultJWKSCacheTTL
+	}
+	return &JWKSCache{oauth: oauth, ttl: ttl}
+}
+
+// jwksCache returns the JWKSCache the service verifies JWTs and ID tokens
+// with, creating it on first use.
+func (s *OAuth2Service) jwksCache() *JWKSCache {
+	s.jwksOnce.Do(func() {
+		s.jwks = newJWKSCache(s, 0)
+	})
+	return s.jwks
+}
+
+// GetKey returns the JWK with the given key ID as its JSON members, such as
+// "kty", "n" and "e". An empty kid returns the only key if the set has
+// exactly one. If the key is not in the set an error matching
+// ErrKeyNotFound is returned. If a fetch fails while an expired copy of the
+// set still holds the key, that key is returned instead of the error.
+func (c *JWKSCache) GetKey(ctx context.Context, kid string) (map[string]any, error) {
+	c.mu.Lock()
+	key := c.lookup(kid)
+	age := time.Since(c.fetched)
+	fetched := !c.fetched.IsZero()
+	c.mu.Unlock()
+	switch {
+	case key != nil && age < c.ttl:
+		return maps.Clone(key), nil
+	case key == nil && fetched && age < min(c.ttl, jwksMinRefreshInterval):
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+	}
+
+	err := c.refresh(ctx)
+	c.mu.Lock()
+	if fresh := c.lookup(kid); fresh != nil || err == nil {
+		key = fresh
+	}
+	c.mu.Unlock()
+	switch {
+	case key != nil:
+		return maps.Clone(key), nil
+	case err != nil:
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+}
+
+// lookup returns the cached key for kid. The caller must hold c.mu.
+func (c *JWKSCache) lookup(kid string) map[string]any {
+	if kid == "" {
+		return c.only
+	}
+	return c.keys[kid]
+}
+
+// refresh fetches the key set, or waits for a fetch already in flight.
+func (c *JWKSCache) refresh(ctx context.Context) error {
+	c.mu.Lock()
+	if call := c.fetching; call != nil {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &jwksFetch{done: make(chan struct{})}
+	c.fetching = call
+	c.mu.Unlock()
+
+	// The fetch is shared, so it must not be cut short by the context of
+	// whichever lookup happened to start it.
+	// A fresh cached copy of the set is bypassed: it is what led here.
+	var set struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	raw, err := c.oauth.http.revalidateCached(context.WithoutCancel(ctx), jwksPath)
+	call.err = decodeResult(raw, err, &set)
+
+	c.mu.Lock()
+	c.fetching = nil
+	if call.err == nil {
+		c.keys = make(map[string]map[string]any, len(set.Keys))
+		for _, k := range set.Keys {
+			if kid, _ := k["kid"].(string); kid != "" {
+				c.keys[kid] = k
+			}
+		}
+		c.only = nil
+		if len(set.Keys) == 1 {
+			c.only = set.Keys[0]
+		}
+		c.fetched = time.Now()
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.err
+}
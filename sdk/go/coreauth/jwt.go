@@ -0,0 +1,287 @@
+package coreauth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// VerifyOptions configures OAuth2Service.VerifyJWT. The zero value checks
+// only the signature and the exp and nbf claims.
+type VerifyOptions struct {
+	// Issuer, if set, must equal the token's iss claim.
+	Issuer string
+	// Audience, if set, must be one of the token's aud values.
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp and nbf.
+	Leeway time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+	// RequireClaims lists claims that must be present in the token.
+	RequireClaims []string
+	// CustomClaims lists claims, such as namespaced claims configured with
+	// AdminService.UpdateTokenClaims, to copy into VerifiedToken.Custom.
+	CustomClaims []string
+}
+
+// VerifiedToken is a JWT whose signature and validity were checked by
+// OAuth2Service.VerifyJWT.
+type VerifiedToken struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ID        string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	// Custom holds the VerifyOptions.CustomClaims present in the token.
+	Custom map[string]any
+	// Claims holds every claim in the token.
+	Claims map[string]any
+}
+
+// jwtHeader is the JOSE header of a JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jsonWebKey is an RSA signing key from a JWKS.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jwtHashes maps the supported signing algorithms to their hash.
+var jwtHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+// VerifyJWT verifies a JWT signed with RS256, RS384, RS512 or ES256 against
+// the key named by its kid, looked up in the same JWKSCache as
+// VerifyIDToken, and checks its exp, nbf, and the claims required by opts,
+// which may be nil. A malformed token returns an error matching
+// ErrInvalidToken; a signature that does not verify, one matching
+// ErrInvalidSignature as well; an expired token one matching
+// ErrTokenExpired; and a missing required claim a *MissingClaimError.
+func (s *OAuth2Service) VerifyJWT(ctx context.Context, token string, opts *VerifyOptions) (*VerifiedToken, error) {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	claims, err := s.verifySignedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return checkClaims(claims, opts)
+}
+
+// verifySignedToken parses a compact JWS, checks its signature against the
+// key its kid names in the service's JWKSCache, and returns its claims.
+func (s *OAuth2Service) verifySignedToken(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrInvalidToken, len(parts))
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+
+	key, err := s.jwksCache().GetKey(ctx, header.Kid)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, fmt.Errorf("%w: %w: %w", ErrInvalidToken, ErrInvalidSignature, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJWS(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, fmt.Errorf("%w: %w: %v", ErrInvalidToken, ErrInvalidSignature, err)
+	}
+	return claims, nil
+}
+
+// checkClaims validates verified claims against opts and builds the result.
+func checkClaims(claims map[string]any, opts *VerifyOptions) (*VerifiedToken, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	t := &VerifiedToken{
+		Subject:   stringClaim(claims, "sub"),
+		Issuer:    stringClaim(claims, "iss"),
+		Audience:  audienceClaim(claims["aud"]),
+		ID:        stringClaim(claims, "jti"),
+		IssuedAt:  timeClaim(claims, "iat"),
+		ExpiresAt: timeClaim(claims, "exp"),
+		Claims:    claims,
+	}
+	if !t.ExpiresAt.IsZero() && now().After(t.ExpiresAt.Add(opts.Leeway)) {
+		return nil, fmt.Errorf("%w: expired at %s", ErrTokenExpired, t.ExpiresAt.Format(time.RFC3339))
+	}
+	if nbf := timeClaim(claims, "nbf"); !nbf.IsZero() && now().Add(opts.Leeway).Before(nbf) {
+		return nil, fmt.Errorf("%w: not valid before %s", ErrInvalidToken, nbf.Format(time.RFC3339))
+	}
+	if opts.Issuer != "" && t.Issuer != opts.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match", ErrInvalidToken, t.Issuer)
+	}
+	if opts.Audience != "" && !containsString(t.Audience, opts.Audience) {
+		return nil, fmt.Errorf("%w: audience does not include %q", ErrInvalidToken, opts.Audience)
+	}
+	for _, name := range opts.RequireClaims {
+		if _, ok := claims[name]; !ok {
+			return nil, &MissingClaimError{Claim: name}
+		}
+	}
+	for _, name := range opts.CustomClaims {
+		if v, ok := claims[name]; ok {
+			if t.Custom == nil {
+				t.Custom = make(map[string]any)
+			}
+			t.Custom[name] = v
+		}
+	}
+	return t, nil
+}
+
+// verifyJWS checks a JWS signature over signed with the JWK key.
+func verifyJWS(alg string, key map[string]any, signed string, sig []byte) error {
+	if a, _ := key["alg"].(string); a != "" && a != alg {
+		return fmt.Errorf("token algorithm %s does not match key algorithm %s", alg, a)
+	}
+	if use, _ := key["use"].(string); use != "" && use != "sig" {
+		return fmt.Errorf("key is for %q, not signing", use)
+	}
+	kty, _ := key["kty"].(string)
+	switch {
+	case jwtHashes[alg] != 0 && kty == "RSA":
+		hash := jwtHashes[alg]
+		pub, err := jsonWebKey{Kid: stringClaim(key, "kid"), N: stringClaim(key, "n"), E: stringClaim(key, "e")}.publicKey()
+		if err != nil {
+			return err
+		}
+		h := hash.New()
+		h.Write([]byte(signed))
+		if rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), sig) != nil {
+			return errors.New("signature does not match")
+		}
+		return nil
+	case alg == "ES256" && kty == "EC":
+		pub, err := ecPublicKey(key)
+		if err != nil {
+			return err
+		}
+		if len(sig) != 64 {
+			return fmt.Errorf("ES256 signature is %d bytes, want 64", len(sig))
+		}
+		h := crypto.SHA256.New()
+		h.Write([]byte(signed))
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, h.Sum(nil), r, s) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported algorithm %q for %s key", alg, kty)
+}
+
+// ecPublicKey converts a P-256 JWK to a public key.
+func ecPublicKey(key map[string]any) (*ecdsa.PublicKey, error) {
+	if crv := stringClaim(key, "crv"); crv != "P-256" {
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(stringClaim(key, "x"))
+	y, errY := base64.RawURLEncoding.DecodeString(stringClaim(key, "y"))
+	if errX != nil || errY != nil {
+		return nil, errors.New("invalid EC key coordinates")
+	}
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("EC key is not on the P-256 curve")
+	}
+	return pub, nil
+}
+
+func (k jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("invalid JWKS key %q: %v", k.Kid, err), Err: err}
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("invalid JWKS key %q: bad exponent", k.Kid)}
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a JWT into out.
+func decodeSegment(seg string, out any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	return dec.Decode(out)
+}
+
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+// timeClaim returns a NumericDate claim as a time, or the zero time if it is
+// absent or not a number.
+func timeClaim(claims map[string]any, name string) time.Time {
+	v, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(v), 0)
+}
+
+// audienceClaim normalizes an aud claim, which may be a string or a list.
+func audienceClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		aud := make([]string, 0, len(v))
+		for _, a := range v {
+			if s, ok := a.(string); ok {
+				aud = append(aud, s)
+			}
+		}
+		return aud
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
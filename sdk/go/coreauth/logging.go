@@ -0,0 +1,115 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Levels passed to StructuredLogger.Log.
+const (
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// StructuredLogger receives log records as a message plus fields rather than
+// a formatted line, so they can be fed to log/slog, zap, or similar.
+type StructuredLogger interface {
+	Log(ctx context.Context, level, msg string, fields map[string]any)
+}
+
+// WithContextLogger logs each request to l with the fields method, path
+// (the URL path with IDs replaced by "{id}", so records group by endpoint),
+// status, latency, attempt, and request_id, plus error for requests that
+// failed without a response. ctx is the request's context. Headers, query
+// strings, and bodies are never logged, so tokens and secrets stay out of
+// the fields. It can be combined with WithLogger.
+func WithContextLogger(l StructuredLogger) Option {
+	return func(c *Client) {
+		c.http.structuredLogger = l
+	}
+}
+
+// structuredLoggingMiddleware logs one record per request.
+func structuredLoggingMiddleware(l StructuredLogger) Middleware {
+	return func(next RoundTripper) RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			fields := map[string]any{
+				"method":  req.Method,
+				"path":    templatePath(req.URL.Path),
+				"latency": time.Since(start),
+				"attempt": 1,
+			}
+			if n, _, ok := AttemptInfo(req.Context()); ok {
+				fields["attempt"] = n
+			}
+			if id := req.Header.Get(RequestIDHeader); id != "" {
+				fields["request_id"] = id
+			}
+			if err != nil {
+				fields["error"] = logError(err)
+				l.Log(req.Context(), LogLevelError, "coreauth: request failed", fields)
+				return resp, err
+			}
+			fields["status"] = resp.StatusCode
+			level := LogLevelInfo
+			if resp.StatusCode >= 500 {
+				level = LogLevelWarn
+			}
+			l.Log(req.Context(), level, "coreauth: request", fields)
+			return resp, nil
+		})
+	}
+}
+
+// logError returns err's message for logging with any URL it carries cut
+// down to its path, as the query string may hold a token.
+func logError(err error) string {
+	msg := err.Error()
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.URL != "" {
+		path := urlErr.URL
+		if u, perr := url.Parse(urlErr.URL); perr == nil {
+			path = u.Path
+		} else if i := strings.IndexAny(path, "?#"); i >= 0 {
+			path = path[:i]
+		}
+		msg = strings.ReplaceAll(msg, urlErr.URL, path)
+	}
+	return msg
+}
+
+// templatePath replaces the path segments that look like IDs (UUIDs,
+// numbers, and long hex strings) with "{id}".
+func templatePath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		if looksLikeID(seg) {
+			segs[i] = "{id}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+func looksLikeID(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	digits, hex := true, true
+	for _, r := range seg {
+		if !unicode.IsDigit(r) {
+			digits = false
+		}
+		if !strings.ContainsRune("0123456789abcdefABCDEF-", r) {
+			hex = false
+		}
+	}
+	return digits || (hex && len(seg) >= 16)
+}
@@ -16,14 +16,82 @@ func (s *MfaService) EnrollTOTP(ctx context.Context) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/mfa/enroll/totp", nil)
 }
 
+// EnrollTOTPInto is like EnrollTOTP but decodes the response into out.
+func (s *MfaService) EnrollTOTPInto(ctx context.Context, out any) error {
+	raw, err := s.EnrollTOTP(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // VerifyTOTP verifies a TOTP code for the given MFA method.
 func (s *MfaService) VerifyTOTP(ctx context.Context, methodID, code string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/mfa/totp/%s/verify", methodID), VerifyMfaRequest{Code: code})
 }
 
+// VerifyTOTPInto is like VerifyTOTP but decodes the response into out.
+func (s *MfaService) VerifyTOTPInto(ctx context.Context, methodID, code string, out any) error {
+	raw, err := s.VerifyTOTP(ctx, methodID, code)
+	return decodeResult(raw, err, out)
+}
+
+// EnrollAndVerifyTOTP enrolls TOTP for the authenticated user and verifies
+// it in one step, for provisioning scripts and tests. totpCode is given the
+// base32 secret from the enrollment, taken from its QR code URI if the
+// response has no separate secret, and must return the current code, e.g.
+// from a TOTP library or a deterministic generator in tests. It returns the
+// activated method. If computing or verifying the code fails the method is
+// left enrolled but unverified.
+func (s *MfaService) EnrollAndVerifyTOTP(ctx context.Context, totpCode func(secret string) (string, error)) (*MfaMethod, error) {
+	var enrolled MfaEnrollResponse
+	if err := s.EnrollTOTPInto(ctx, &enrolled); err != nil {
+		return nil, err
+	}
+	var secret string
+	switch {
+	case enrolled.Secret != nil && *enrolled.Secret != "":
+		secret = *enrolled.Secret
+	case enrolled.QrCodeURI != nil:
+		params, err := ParseOTPAuthURI(*enrolled.QrCodeURI)
+		if err != nil {
+			return nil, err
+		}
+		secret = params.Secret
+	default:
+		return nil, &CoreAuthError{Message: "TOTP enrollment response did not include a secret"}
+	}
+	code, err := totpCode(secret)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to compute TOTP code: %v", err), Err: err}
+	}
+	if _, err := s.VerifyTOTP(ctx, enrolled.MethodID, code); err != nil {
+		return nil, err
+	}
+	methods, err := s.ListMethodsTyped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range methods {
+		if methods[i].ID == enrolled.MethodID {
+			return &methods[i], nil
+		}
+	}
+	return &MfaMethod{ID: enrolled.MethodID, MethodType: enrolled.MethodType, Verified: true}, nil
+}
+
 // EnrollSMS initiates SMS-based MFA enrollment with the given phone number.
+// The number must include its country code; it is normalized to E.164 with
+// NormalizePhone and a *ValidationError is returned if that fails.
 func (s *MfaService) EnrollSMS(ctx context.Context, phoneNumber string) (json.RawMessage, error) {
-	return s.http.post(ctx, "/api/mfa/enroll/sms", EnrollSmsRequest{PhoneNumber: phoneNumber})
+	normalized, err := NormalizePhone(phoneNumber, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.http.post(ctx, "/api/mfa/enroll/sms", EnrollSmsRequest{PhoneNumber: normalized})
+}
+
+// EnrollSMSInto is like EnrollSMS but decodes the response into out.
+func (s *MfaService) EnrollSMSInto(ctx context.Context, phoneNumber string, out any) error {
+	raw, err := s.EnrollSMS(ctx, phoneNumber)
+	return decodeResult(raw, err, out)
 }
 
 // VerifySMS verifies an SMS code for the given MFA method.
@@ -31,16 +99,43 @@ func (s *MfaService) VerifySMS(ctx context.Context, methodID, code string) (json
 	return s.http.post(ctx, fmt.Sprintf("/api/mfa/sms/%s/verify", methodID), VerifyMfaRequest{Code: code})
 }
 
+// VerifySMSInto is like VerifySMS but decodes the response into out.
+func (s *MfaService) VerifySMSInto(ctx context.Context, methodID, code string, out any) error {
+	raw, err := s.VerifySMS(ctx, methodID, code)
+	return decodeResult(raw, err, out)
+}
+
 // ResendSMS resends the SMS verification code for the given MFA method.
 func (s *MfaService) ResendSMS(ctx context.Context, methodID string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/mfa/sms/%s/resend", methodID), nil)
 }
 
+// ResendSMSInto is like ResendSMS but decodes the response into out.
+func (s *MfaService) ResendSMSInto(ctx context.Context, methodID string, out any) error {
+	raw, err := s.ResendSMS(ctx, methodID)
+	return decodeResult(raw, err, out)
+}
+
 // ListMethods returns all MFA methods configured for the authenticated user.
 func (s *MfaService) ListMethods(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/mfa/methods", nil)
 }
 
+// ListMethodsInto is like ListMethods but decodes the response into out.
+func (s *MfaService) ListMethodsInto(ctx context.Context, out any) error {
+	raw, err := s.ListMethods(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// ListMethodsTyped returns all MFA methods configured for the authenticated user.
+func (s *MfaService) ListMethodsTyped(ctx context.Context) ([]MfaMethod, error) {
+	var methods []MfaMethod
+	if err := s.ListMethodsInto(ctx, &methods); err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
 // DeleteMethod removes an MFA method by its ID.
 func (s *MfaService) DeleteMethod(ctx context.Context, methodID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/mfa/methods/%s", methodID), nil)
@@ -52,6 +147,12 @@ func (s *MfaService) RegenerateBackupCodes(ctx context.Context) (json.RawMessage
 	return s.http.post(ctx, "/api/mfa/backup-codes/regenerate", nil)
 }
 
+// RegenerateBackupCodesInto is like RegenerateBackupCodes but decodes the response into out.
+func (s *MfaService) RegenerateBackupCodesInto(ctx context.Context, out any) error {
+	raw, err := s.RegenerateBackupCodes(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // EnrollTOTPWithToken initiates TOTP enrollment using an enrollment token (pre-auth flow).
 func (s *MfaService) EnrollTOTPWithToken(ctx context.Context, enrollmentToken string) (json.RawMessage, error) {
 	return s.http.post(ctx, "/api/mfa/enroll-with-token/totp", EnrollWithTokenRequest{
@@ -59,6 +160,12 @@ func (s *MfaService) EnrollTOTPWithToken(ctx context.Context, enrollmentToken st
 	})
 }
 
+// EnrollTOTPWithTokenInto is like EnrollTOTPWithToken but decodes the response into out.
+func (s *MfaService) EnrollTOTPWithTokenInto(ctx context.Context, enrollmentToken string, out any) error {
+	raw, err := s.EnrollTOTPWithToken(ctx, enrollmentToken)
+	return decodeResult(raw, err, out)
+}
+
 // VerifyTOTPWithToken verifies a TOTP code using an enrollment token (pre-auth flow).
 func (s *MfaService) VerifyTOTPWithToken(ctx context.Context, methodID, enrollmentToken, code string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/mfa/verify-with-token/totp/%s", methodID), VerifyWithTokenRequest{
@@ -66,3 +173,9 @@ func (s *MfaService) VerifyTOTPWithToken(ctx context.Context, methodID, enrollme
 		Code:            code,
 	})
 }
+
+// VerifyTOTPWithTokenInto is like VerifyTOTPWithToken but decodes the response into out.
+func (s *MfaService) VerifyTOTPWithTokenInto(ctx context.Context, methodID, enrollmentToken, code string, out any) error {
+	raw, err := s.VerifyTOTPWithToken(ctx, methodID, enrollmentToken, code)
+	return decodeResult(raw, err, out)
+}
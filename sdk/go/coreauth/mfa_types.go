@@ -2,10 +2,10 @@ package coreauth
 
 // MfaEnrollResponse represents the response from enrolling in MFA (TOTP).
 type MfaEnrollResponse struct {
-	MethodID   string   `json:"method_id"`
-	MethodType string   `json:"method_type"`
-	Secret     *string  `json:"secret,omitempty"`
-	QrCodeURI  *string  `json:"qr_code_uri,omitempty"`
+	MethodID    string   `json:"method_id"`
+	MethodType  string   `json:"method_type"`
+	Secret      *string  `json:"secret,omitempty"`
+	QrCodeURI   *string  `json:"qr_code_uri,omitempty"`
 	BackupCodes []string `json:"backup_codes,omitempty"`
 }
 
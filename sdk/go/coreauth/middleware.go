@@ -0,0 +1,188 @@
+package coreauth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RoundTripper sends a single HTTP request and is the unit composed by
+// Middleware. Any http.RoundTripper, such as *http.Transport, satisfies it.
+type RoundTripper = http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to a RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the next stage of the request pipeline. A middleware may
+// inspect or modify the request, short-circuit with its own response, or
+// observe the response returned by next. Middlewares must not modify the
+// request they receive; clone it first with req.Clone.
+type Middleware func(next RoundTripper) RoundTripper
+
+// Logger receives request log lines. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// WithMiddleware appends middlewares to the request pipeline. The first
+// middleware registered is the outermost; all of them run before the
+// built-in token injection, so a middleware that sets its own Authorization
+// header takes precedence over the client's token.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.http.middlewares = append(c.http.middlewares, mw...)
+	}
+}
+
+// WithLogger logs each request's method, URL path, status, and duration to
+// l. Headers, query strings, and bodies are never logged, so tokens sent as
+// query parameters stay out of the log.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.http.logger = l
+	}
+}
+
+// warnf logs a warning to the client's Logger and StructuredLogger, if
+// configured.
+func (c *httpClient) warnf(ctx context.Context, format string, v ...any) {
+	if c.logger != nil {
+		c.logger.Printf("coreauth: warning: "+format, v...)
+	}
+	if c.structuredLogger != nil {
+		c.structuredLogger.Log(ctx, LogLevelWarn, "coreauth: "+fmt.Sprintf(format, v...), nil)
+	}
+}
+
+// RequestMutator edits an outgoing request just before it is sent, for
+// example to add a tenant header resolved from ctx, propagate baggage, or
+// sign the request. Returning an error aborts the request.
+type RequestMutator func(ctx context.Context, req *http.Request) error
+
+// WithRequestMutator registers mutators that run, in order, on every
+// request after the client's own headers and token have been applied, so
+// they see the request exactly as it will be sent. Each receives a private
+// copy of the request and may modify it freely. Unlike WithMiddleware, a
+// mutator cannot see or replace the response.
+func WithRequestMutator(fn ...RequestMutator) Option {
+	return func(c *Client) {
+		c.http.mutators = append(c.http.mutators, fn...)
+	}
+}
+
+// pipeline assembles the request pipeline. From outermost to innermost it
+// runs conflict retries, logging (plain, then structured), user
+// middlewares, refresh on 401, token injection, request mutators, and
+// finally the transport (or the dry-run sink). Retries sit outside logging
+// so every attempt is logged.
+func (c *httpClient) pipeline() RoundTripper {
+	var rt RoundTripper = RoundTripperFunc(c.send)
+	if len(c.mutators) > 0 {
+		rt = c.mutatorMiddleware(rt)
+	}
+	rt = c.tokenMiddleware(rt)
+	rt = c.refreshMiddleware(rt)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	if c.logger != nil {
+		rt = loggingMiddleware(c.logger)(rt)
+	}
+	if c.structuredLogger != nil {
+		rt = structuredLoggingMiddleware(c.structuredLogger)(rt)
+	}
+	if c.conflictAttempts > 1 {
+		rt = c.conflictRetryMiddleware(rt)
+	}
+	return rt
+}
+
+// send is the terminal stage: it hands the request to the dry-run sink if
+// one is configured and to the underlying http.Client otherwise.
+func (c *httpClient) send(req *http.Request) (*http.Response, error) {
+	if c.dryRun == nil {
+		if isStreamRequest(req.Context()) && c.httpClient.Timeout > 0 {
+			// The client timeout covers reading the whole body, which
+			// would cut long-lived streams off; rely on ctx instead.
+			hc := *c.httpClient
+			hc.Timeout = 0
+			return hc.Do(req)
+		}
+		return c.httpClient.Do(req)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.dryRun(req.Method, req.URL.String(), body)
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
+
+// tokenMiddleware adds the client's bearer token unless the request already
+// carries an Authorization header.
+func (c *httpClient) tokenMiddleware(next RoundTripper) RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if token := c.currentToken(); token != "" && req.Header.Get("Authorization") == "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+// mutatorMiddleware applies the registered request mutators to a copy of
+// the request.
+func (c *httpClient) mutatorMiddleware(next RoundTripper) RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		for _, mutate := range c.mutators {
+			if err := mutate(req.Context(), req); err != nil {
+				if req.Body != nil {
+					req.Body.Close()
+				}
+				return nil, fmt.Errorf("request mutator: %w", err)
+			}
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+// loggingMiddleware logs one line per request.
+func loggingMiddleware(l Logger) Middleware {
+	return func(next RoundTripper) RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start).Round(time.Millisecond)
+			label := req.Method + " " + req.URL.Path
+			if n, _, ok := AttemptInfo(req.Context()); ok && n > 1 {
+				label = fmt.Sprintf("%s (attempt %d)", label, n)
+			}
+			if err != nil {
+				l.Printf("coreauth: %s failed after %s: %s", label, elapsed, logError(err))
+				return resp, err
+			}
+			l.Printf("coreauth: %s -> %d (%s)", label, resp.StatusCode, elapsed)
+			return resp, nil
+		})
+	}
+}
@@ -2,27 +2,52 @@ package coreauth
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/url"
+	"strings"
+	"sync"
 )
 
 // OAuth2Service provides OAuth2 and OpenID Connect operations.
 type OAuth2Service struct {
 	http *httpClient
+
+	jwksOnce sync.Once
+	jwks     *JWKSCache
 }
 
-// Discovery retrieves the OpenID Connect discovery document.
+// Discovery retrieves the OpenID Connect discovery document. The response
+// is cached and revalidated with its ETag; see WithMetadataCacheTTL.
 func (s *OAuth2Service) Discovery(ctx context.Context) (json.RawMessage, error) {
-	return s.http.get(ctx, "/.well-known/openid-configuration", nil)
+	return s.http.getCached(ctx, "/.well-known/openid-configuration")
+}
+
+// DiscoveryInto is like Discovery but decodes the response into out.
+func (s *OAuth2Service) DiscoveryInto(ctx context.Context, out any) error {
+	raw, err := s.Discovery(ctx)
+	return decodeResult(raw, err, out)
 }
 
-// JWKS retrieves the JSON Web Key Set used for token verification.
+// jwksPath is the path of the server's JSON Web Key Set.
+const jwksPath = "/.well-known/jwks.json"
+
+// JWKS retrieves the JSON Web Key Set used for token verification. The
+// response is cached and revalidated with its ETag; see WithMetadataCacheTTL.
 func (s *OAuth2Service) JWKS(ctx context.Context) (json.RawMessage, error) {
-	return s.http.get(ctx, "/.well-known/jwks.json", nil)
+	return s.http.getCached(ctx, jwksPath)
+}
+
+// JWKSInto is like JWKS but decodes the response into out.
+func (s *OAuth2Service) JWKSInto(ctx context.Context, out any) error {
+	raw, err := s.JWKS(ctx)
+	return decodeResult(raw, err, out)
 }
 
 // AuthorizeURL constructs an OAuth2 authorization URL. This method does not
-// make an HTTP request; it returns the fully-formed URL string.
+// make an HTTP request; it returns the fully-formed URL string. params may
+// include "state", e.g. from GenerateState, which the server echoes back on
+// the callback for ValidateState.
 func (s *OAuth2Service) AuthorizeURL(clientID, redirectURI string, params map[string]string) string {
 	v := url.Values{}
 	v.Set("client_id", clientID)
@@ -36,16 +61,90 @@ func (s *OAuth2Service) AuthorizeURL(clientID, redirectURI string, params map[st
 	return s.http.baseURL + "/authorize?" + v.Encode()
 }
 
+// AuthorizeURLOpts is like AuthorizeURL but takes typed options, so scopes
+// are space-joined and numeric parameters encoded correctly.
+func (s *OAuth2Service) AuthorizeURLOpts(clientID, redirectURI string, opts AuthorizeOptions) string {
+	return s.AuthorizeURL(clientID, redirectURI, opts.params())
+}
+
 // Token exchanges an authorization code or refresh token for tokens.
 func (s *OAuth2Service) Token(ctx context.Context, data url.Values) (json.RawMessage, error) {
 	return s.http.postForm(ctx, "/oauth/token", data)
 }
 
+// TokenInto is like Token but decodes the response into out.
+func (s *OAuth2Service) TokenInto(ctx context.Context, data url.Values, out any) error {
+	raw, err := s.Token(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
+// grantToken requests tokens from the token endpoint with the grant in data
+// and, with WithAutoStoreToken, stores the access token on the client.
+func (s *OAuth2Service) grantToken(ctx context.Context, data url.Values) (*TokenResponse, error) {
+	var resp TokenResponse
+	if err := s.TokenInto(ctx, data, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, &CoreAuthError{Message: "token response did not include an access token"}
+	}
+	s.http.storeIssuedToken(resp.AccessToken)
+	return &resp, nil
+}
+
+// ExchangeCode exchanges an authorization code, received on the redirect
+// from AuthorizeURL, for tokens with the authorization_code grant. An
+// expired or already used code fails with an *ApiError matching
+// ErrInvalidGrant. Verify the returned ID token with VerifyIDToken. With
+// WithAutoStoreToken the access token is stored on the client.
+func (s *OAuth2Service) ExchangeCode(ctx context.Context, req ExchangeCodeRequest) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", req.Code)
+	data.Set("redirect_uri", req.RedirectURI)
+	data.Set("client_id", req.ClientID)
+	if req.ClientSecret != "" {
+		data.Set("client_secret", req.ClientSecret)
+	}
+	if req.CodeVerifier != "" {
+		data.Set("code_verifier", req.CodeVerifier)
+	}
+	return s.grantToken(ctx, data)
+}
+
+// ClientCredentials obtains a token for a machine-to-machine client with the
+// client_credentials grant. The credentials are sent in the form body, or,
+// if basicAuth is set, in an HTTP Basic Authorization header as some
+// deployments require. A rejected request fails with an *ApiError carrying
+// the OAuth2 error code (e.g. invalid_client) and its error_description.
+// With WithAutoStoreToken the access token is stored on the client.
+func (s *OAuth2Service) ClientCredentials(ctx context.Context, clientID, clientSecret string, scopes []string, basicAuth bool) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	if basicAuth {
+		creds := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+		ctx = withRequestHeader(ctx, "Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(creds)))
+	} else {
+		data.Set("client_id", clientID)
+		data.Set("client_secret", clientSecret)
+	}
+	if len(scopes) > 0 {
+		data.Set("scope", strings.Join(scopes, " "))
+	}
+	return s.grantToken(ctx, data)
+}
+
 // Userinfo retrieves the authenticated user's claims from the UserInfo endpoint.
 func (s *OAuth2Service) Userinfo(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/userinfo", nil)
 }
 
+// UserinfoInto is like Userinfo but decodes the response into out.
+func (s *OAuth2Service) UserinfoInto(ctx context.Context, out any) error {
+	raw, err := s.Userinfo(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // Revoke revokes an access or refresh token.
 func (s *OAuth2Service) Revoke(ctx context.Context, token string, tokenTypeHint *string) (json.RawMessage, error) {
 	data := url.Values{}
@@ -56,6 +155,12 @@ func (s *OAuth2Service) Revoke(ctx context.Context, token string, tokenTypeHint
 	return s.http.postForm(ctx, "/oauth/revoke", data)
 }
 
+// RevokeInto is like Revoke but decodes the response into out.
+func (s *OAuth2Service) RevokeInto(ctx context.Context, token string, tokenTypeHint *string, out any) error {
+	raw, err := s.Revoke(ctx, token, tokenTypeHint)
+	return decodeResult(raw, err, out)
+}
+
 // Introspect inspects a token and returns its metadata.
 func (s *OAuth2Service) Introspect(ctx context.Context, token string, tokenTypeHint *string) (json.RawMessage, error) {
 	data := url.Values{}
@@ -66,7 +171,30 @@ func (s *OAuth2Service) Introspect(ctx context.Context, token string, tokenTypeH
 	return s.http.postForm(ctx, "/oauth/introspect", data)
 }
 
+// IntrospectInto is like Introspect but decodes the response into out.
+func (s *OAuth2Service) IntrospectInto(ctx context.Context, token string, tokenTypeHint *string, out any) error {
+	raw, err := s.Introspect(ctx, token, tokenTypeHint)
+	return decodeResult(raw, err, out)
+}
+
+// IntrospectTyped is like Introspect but returns a typed response. An
+// inactive, expired, or unknown token is not an error: the response has
+// Active set to false.
+func (s *OAuth2Service) IntrospectTyped(ctx context.Context, token string, tokenTypeHint *string) (*IntrospectionResponse, error) {
+	var resp IntrospectionResponse
+	if err := s.IntrospectInto(ctx, token, tokenTypeHint, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // OidcLogout initiates an OIDC RP-Initiated Logout flow.
 func (s *OAuth2Service) OidcLogout(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/logout", params)
 }
+
+// OidcLogoutInto is like OidcLogout but decodes the response into out.
+func (s *OAuth2Service) OidcLogoutInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.OidcLogout(ctx, params)
+	return decodeResult(raw, err, out)
+}
@@ -0,0 +1,83 @@
+package coreauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateEntropy is the number of random bytes in a GenerateState value.
+const stateEntropy = 32
+
+// GenerateState returns a random, URL-safe OAuth2 state value carrying 32
+// bytes of entropy. Pass it as "state" to AuthorizeURL (or as
+// AuthorizeOptions.State), keep it in the user's session, and check the
+// state returned on the callback with ValidateState to defend against CSRF.
+func GenerateState() string {
+	b := make([]byte, stateEntropy)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ValidateState reports whether the state returned on an OAuth2 callback
+// matches the one sent with the authorization request. The comparison runs
+// in constant time, and an empty expected state never matches.
+func ValidateState(expected, got string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(got)) == 1
+}
+
+// SignedState returns a stateless OAuth2 state value that carries returnURL
+// and expires after ttl, for use with AuthorizeURL. The state is signed with
+// an HMAC-SHA256 of secret, which should be at least 32 random bytes kept on
+// the server; check it on the callback with VerifySignedState. The return URL
+// is readable by anyone holding the state, so do not put secrets in it.
+func SignedState(secret []byte, returnURL string, ttl time.Duration) string {
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	payload := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10) + "." + hex.EncodeToString(nonce) + "." + returnURL
+	enc := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return enc + "." + base64.RawURLEncoding.EncodeToString(signState(secret, enc))
+}
+
+// VerifySignedState checks a state value created by SignedState and returns
+// the return URL it carries. It returns ErrStateInvalid if the state is
+// malformed or was not signed with secret, and ErrStateExpired if its TTL has
+// passed.
+func VerifySignedState(secret []byte, state string) (returnURL string, err error) {
+	enc, sig, ok := strings.Cut(state, ".")
+	if !ok || len(secret) == 0 {
+		return "", ErrStateInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, signState(secret, enc)) {
+		return "", ErrStateInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil {
+		return "", ErrStateInvalid
+	}
+	parts := strings.SplitN(string(payload), ".", 3)
+	if len(parts) != 3 {
+		return "", ErrStateInvalid
+	}
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", ErrStateInvalid
+	}
+	if time.Now().Unix() > expires {
+		return "", ErrStateExpired
+	}
+	return parts[2], nil
+}
+
+func signState(secret []byte, payload string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
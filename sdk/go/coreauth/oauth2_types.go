@@ -1,5 +1,11 @@
 package coreauth
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 // TokenResponse represents an OAuth2 token response.
 type TokenResponse struct {
 	AccessToken  string  `json:"access_token"`
@@ -40,6 +46,20 @@ type IntrospectionResponse struct {
 	Jti       *string `json:"jti,omitempty"`
 }
 
+// IsExpired reports whether the token's exp has passed at now. A response
+// without exp, such as the bare {"active": false} returned for an unknown
+// token, is never expired; check Active or use IsActive.
+func (r *IntrospectionResponse) IsExpired(now time.Time) bool {
+	return r.Exp != nil && !now.Before(time.Unix(*r.Exp, 0))
+}
+
+// IsActive reports whether the server considers the token active and its
+// exp, if any, has not passed at now, guarding against a response that was
+// cached past the token's expiry.
+func (r *IntrospectionResponse) IsActive(now time.Time) bool {
+	return r.Active && !r.IsExpired(now)
+}
+
 // OidcDiscovery represents the OIDC Discovery document.
 type OidcDiscovery struct {
 	Issuer                string         `json:"issuer"`
@@ -54,3 +74,59 @@ type OidcDiscovery struct {
 type Jwks struct {
 	Keys []map[string]any `json:"keys"`
 }
+
+// ExchangeCodeRequest is an authorization code exchange made with
+// OAuth2Service.ExchangeCode.
+type ExchangeCodeRequest struct {
+	ClientID string
+	// ClientSecret authenticates a confidential client; leave it empty for
+	// a public client such as a SPA or native app.
+	ClientSecret string
+	Code         string
+	// RedirectURI must be the redirect_uri of the authorization request.
+	RedirectURI string
+	// CodeVerifier is the PKCE verifier, if the authorization request sent
+	// a code_challenge.
+	CodeVerifier string
+}
+
+// AuthorizeOptions holds the optional parameters of an authorization
+// request built by OAuth2Service.AuthorizeURLOpts. Zero values are omitted.
+type AuthorizeOptions struct {
+	// Scopes are joined with spaces into the scope parameter.
+	Scopes []string
+	State  string
+	Nonce  string
+	// Prompt is e.g. "login", "consent", or "none".
+	Prompt    string
+	LoginHint string
+	// MaxAge is the maximum authentication age in seconds. It is a pointer
+	// because max_age=0, forcing re-authentication, is meaningful.
+	MaxAge       *int
+	ResponseMode string
+	// Extra holds any other parameters. Typed fields take precedence.
+	Extra map[string]string
+}
+
+// params encodes the options as authorization request parameters.
+func (o AuthorizeOptions) params() map[string]string {
+	p := make(map[string]string, len(o.Extra)+7)
+	for k, v := range o.Extra {
+		p[k] = v
+	}
+	set := func(k, v string) {
+		if v != "" {
+			p[k] = v
+		}
+	}
+	set("scope", strings.Join(o.Scopes, " "))
+	set("state", o.State)
+	set("nonce", o.Nonce)
+	set("prompt", o.Prompt)
+	set("login_hint", o.LoginHint)
+	set("response_mode", o.ResponseMode)
+	if o.MaxAge != nil {
+		p["max_age"] = strconv.Itoa(*o.MaxAge)
+	}
+	return p
+}
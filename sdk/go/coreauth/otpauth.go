@@ -0,0 +1,86 @@
+package coreauth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OTPAuthParams are the parts of an otpauth:// TOTP key URI, as found in
+// MfaEnrollResponse.QrCodeURI. Missing optional parameters are filled in
+// with the defaults authenticator apps assume.
+type OTPAuthParams struct {
+	Issuer      string
+	AccountName string
+	// Secret is the shared secret, base32 encoded without padding.
+	Secret string
+	// Algorithm is SHA1, SHA256 or SHA512; it defaults to SHA1.
+	Algorithm string
+	// Digits is the code length; it defaults to 6.
+	Digits int
+	// Period is the code lifetime in seconds; it defaults to 30.
+	Period int
+}
+
+// ParseOTPAuthURI parses a TOTP key URI of the form
+// otpauth://totp/Issuer:account?secret=...&issuer=Issuer, so an app can
+// render its own QR code or show the secret for manual entry. The issuer
+// parameter takes precedence over the issuer prefix of the label. A
+// *ValidationError is returned if the URI is not an otpauth:// TOTP URI or
+// its secret, algorithm, digits or period are invalid.
+func ParseOTPAuthURI(uri string) (*OTPAuthParams, error) {
+	invalid := func(msg string) error {
+		return &ValidationError{Field: "otpauth URI", Message: msg}
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, invalid(err.Error())
+	}
+	if u.Scheme != "otpauth" {
+		return nil, invalid("scheme must be otpauth, not " + strconv.Quote(u.Scheme))
+	}
+	if u.Host != "totp" {
+		return nil, invalid("type must be totp, not " + strconv.Quote(u.Host))
+	}
+	q := u.Query()
+	p := &OTPAuthParams{
+		AccountName: strings.TrimPrefix(u.Path, "/"),
+		Secret:      strings.ToUpper(strings.TrimRight(q.Get("secret"), "=")),
+		Algorithm:   "SHA1",
+		Digits:      6,
+		Period:      30,
+	}
+	if issuer, account, ok := strings.Cut(p.AccountName, ":"); ok {
+		p.Issuer = strings.TrimSpace(issuer)
+		p.AccountName = strings.TrimSpace(account)
+	}
+	if issuer := q.Get("issuer"); issuer != "" {
+		p.Issuer = issuer
+	}
+	if p.Secret == "" {
+		return nil, invalid("secret is missing")
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(p.Secret); err != nil {
+		return nil, invalid("secret is not valid base32")
+	}
+	if alg := q.Get("algorithm"); alg != "" {
+		p.Algorithm = strings.ToUpper(alg)
+		switch p.Algorithm {
+		case "SHA1", "SHA256", "SHA512":
+		default:
+			return nil, invalid("unsupported algorithm " + strconv.Quote(alg))
+		}
+	}
+	if d := q.Get("digits"); d != "" {
+		if p.Digits, err = strconv.Atoi(d); err != nil || p.Digits < 6 || p.Digits > 8 {
+			return nil, invalid("digits must be 6, 7 or 8")
+		}
+	}
+	if s := q.Get("period"); s != "" {
+		if p.Period, err = strconv.Atoi(s); err != nil || p.Period <= 0 {
+			return nil, invalid("period must be a positive number of seconds")
+		}
+	}
+	return p, nil
+}
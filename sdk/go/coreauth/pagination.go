@@ -0,0 +1,111 @@
+package coreauth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// defaultPageSize is the page size used by the ListAll helpers.
+const defaultPageSize = 100
+
+// PageFunc fetches the page identified by cursor and returns its items along
+// with the cursor of the following page, or "" if it is the last page. The
+// first page is requested with an empty cursor.
+type PageFunc[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// Paginator walks a paginated listing one page at a time. Pagination stops at
+// the first empty page, when the server returns no next cursor, or when it
+// returns the same cursor again. A failed fetch stops the paginator and its
+// error is returned from every later call to Next.
+type Paginator[T any] struct {
+	fetch  PageFunc[T]
+	cursor string
+	done   bool
+	err    error
+}
+
+// NewPaginator returns a Paginator that fetches pages with fetch.
+func NewPaginator[T any](fetch PageFunc[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// More reports whether another call to Next may return items.
+func (p *Paginator[T]) More() bool {
+	return !p.done && p.err == nil
+}
+
+// Next fetches the next page. Once pagination has finished it returns a nil
+// slice and a nil error.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.done {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	items, next, err := p.fetch(ctx, p.cursor)
+	if err != nil {
+		p.err = err
+		return nil, err
+	}
+	if len(items) == 0 || next == "" || next == p.cursor {
+		p.done = true
+	}
+	p.cursor = next
+	return items, nil
+}
+
+// All fetches every remaining page and returns the concatenated items. If a
+// page fails, the items collected so far are returned with the error.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	all := []T{}
+	for p.More() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// offsetPageFunc adapts an offset/limit listing to a PageFunc whose cursor is
+// the decimal offset. fetch returns the items at offset and the total number
+// of items; pagination ends once the total is reached or a short page is
+// returned. Unpaginated endpoints can report offset+len(items) as the total.
+func offsetPageFunc[T any](limit int, fetch func(ctx context.Context, offset, limit int) ([]T, int, error)) PageFunc[T] {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	return func(ctx context.Context, cursor string) ([]T, string, error) {
+		offset := 0
+		if cursor != "" {
+			var err error
+			if offset, err = strconv.Atoi(cursor); err != nil {
+				return nil, "", &CoreAuthError{Message: fmt.Sprintf("invalid page cursor %q", cursor)}
+			}
+		}
+		items, total, err := fetch(ctx, offset, limit)
+		if err != nil {
+			return nil, "", err
+		}
+		next := offset + len(items)
+		if len(items) < limit || next >= total {
+			return items, "", nil
+		}
+		return items, strconv.Itoa(next), nil
+	}
+}
+
+// copyParams returns a copy of params that can be modified safely.
+func copyParams(params map[string]string) map[string]string {
+	q := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		q[k] = v
+	}
+	return q
+}
@@ -0,0 +1,80 @@
+package coreauth
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultPasswordMinLength is the server's minimum password length when an
+// organization does not set one.
+const defaultPasswordMinLength = 8
+
+// ValidatePassword checks password against an organization's password
+// policy, such as the SecuritySettings returned by
+// TenantsService.GetSecurityTyped, so weak passwords can be rejected before a
+// request is sent. Policy fields that are nil, or a nil policy, fall back to
+// the server defaults: at least 8 characters with an uppercase letter, a
+// lowercase letter and a number. All unmet rules are
+// reported together in a single *ValidationError for the "password" field.
+func ValidatePassword(password string, policy *SecuritySettings) error {
+	if problems := passwordProblems(password, policy); len(problems) > 0 {
+		return &ValidationError{Field: "password", Message: strings.Join(problems, "; ")}
+	}
+	return nil
+}
+
+// passwordProblems lists the policy rules password does not meet.
+func passwordProblems(password string, policy *SecuritySettings) []string {
+	if policy == nil {
+		policy = &SecuritySettings{}
+	}
+	minLength := defaultPasswordMinLength
+	if policy.PasswordMinLength != nil {
+		minLength = *policy.PasswordMinLength
+	}
+	requireUpper := boolOr(policy.PasswordRequireUppercase, true)
+	requireLower := boolOr(policy.PasswordRequireLowercase, true)
+	requireNumber := boolOr(policy.PasswordRequireNumber, true)
+	requireSpecial := boolOr(policy.PasswordRequireSpecial, false)
+
+	var hasUpper, hasLower, hasNumber, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsNumber(r):
+			hasNumber = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	var problems []string
+	if utf8.RuneCountInString(password) < minLength {
+		problems = append(problems, "must be at least "+strconv.Itoa(minLength)+" characters")
+	}
+	if requireUpper && !hasUpper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+	if requireLower && !hasLower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+	if requireNumber && !hasNumber {
+		problems = append(problems, "must contain a number")
+	}
+	if requireSpecial && !hasSpecial {
+		problems = append(problems, "must contain a special character")
+	}
+	return problems
+}
+
+func boolOr(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
@@ -0,0 +1,112 @@
+package coreauth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// phoneRegion describes how national numbers are written in a region.
+type phoneRegion struct {
+	callingCode string
+	trunkPrefix string
+}
+
+// phoneRegions maps ISO 3166-1 alpha-2 region codes to their dialing rules.
+var phoneRegions = map[string]phoneRegion{
+	"US": {"1", ""},
+	"CA": {"1", ""},
+	"GB": {"44", "0"},
+	"IE": {"353", "0"},
+	"DE": {"49", "0"},
+	"FR": {"33", "0"},
+	"ES": {"34", ""},
+	"IT": {"39", ""},
+	"NL": {"31", "0"},
+	"BE": {"32", "0"},
+	"CH": {"41", "0"},
+	"AT": {"43", "0"},
+	"SE": {"46", "0"},
+	"NO": {"47", ""},
+	"DK": {"45", ""},
+	"FI": {"358", "0"},
+	"PL": {"48", ""},
+	"PT": {"351", ""},
+	"AU": {"61", "0"},
+	"NZ": {"64", "0"},
+	"IN": {"91", "0"},
+	"SG": {"65", ""},
+	"JP": {"81", "0"},
+	"KR": {"82", "0"},
+	"CN": {"86", "0"},
+	"BR": {"55", "0"},
+	"MX": {"52", ""},
+	"ZA": {"27", "0"},
+	"AE": {"971", "0"},
+	"IL": {"972", "0"},
+}
+
+// NormalizePhone converts a phone number to E.164 form (e.g. "+14155550100").
+// Numbers written with a leading "+" or "00" are treated as international;
+// otherwise defaultRegion (an ISO 3166-1 alpha-2 code such as "US") supplies
+// the country calling code and any national trunk prefix is dropped. If
+// defaultRegion is empty, only international numbers are accepted. A trunk
+// prefix written in parentheses, as in "+44 (0) 20 7946 0958", is dropped.
+// Spaces, dashes, dots, and other parentheses are ignored. A
+// *ValidationError is returned if the number cannot be normalized.
+func NormalizePhone(raw, defaultRegion string) (string, error) {
+	invalid := func(msg string) error {
+		return &ValidationError{Field: "phone_number", Message: msg}
+	}
+
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return "", invalid("phone number is required")
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		international = true
+		s = s[1:]
+	case strings.HasPrefix(s, "00"):
+		international = true
+		s = s[2:]
+	}
+
+	var digits strings.Builder
+	for _, r := range strings.ReplaceAll(s, "(0)", "") {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", invalid(fmt.Sprintf("phone number %q contains invalid character %q", raw, r))
+		}
+	}
+	number := digits.String()
+
+	if !international {
+		if defaultRegion == "" {
+			return "", invalid(fmt.Sprintf("phone number %q must include a country code, e.g. +14155550100", raw))
+		}
+		region, ok := phoneRegions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", invalid(fmt.Sprintf("unsupported region %q; write the number in international form starting with +", defaultRegion))
+		}
+		if region.callingCode == "1" && len(number) == 11 && strings.HasPrefix(number, "1") {
+			number = number[1:]
+		}
+		if region.trunkPrefix != "" {
+			number = strings.TrimPrefix(number, region.trunkPrefix)
+		}
+		number = region.callingCode + number
+	}
+
+	if strings.HasPrefix(number, "0") {
+		return "", invalid(fmt.Sprintf("phone number %q has an invalid country code", raw))
+	}
+	if len(number) < 8 || len(number) > 15 {
+		return "", invalid(fmt.Sprintf("phone number %q must have between 8 and 15 digits including the country code", raw))
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,104 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ReconcileResult summarizes a sync operation that brings server state in
+// line with a desired set. Items are identified by their IDs (or, for
+// tuples, their canonical string form). Items that failed are listed in
+// Errors and not in Added or Removed.
+type ReconcileResult struct {
+	Added     []string
+	Removed   []string
+	Unchanged []string
+	Errors    []ReconcileError
+}
+
+// ReconcileError records a single item that could not be added or removed.
+type ReconcileError struct {
+	Item string
+	Err  error
+}
+
+func (e ReconcileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Item, e.Err)
+}
+
+func (e ReconcileError) Unwrap() error {
+	return e.Err
+}
+
+// Err returns the item errors joined into one error, or nil if every change
+// succeeded.
+func (r *ReconcileResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Errors))
+	for i, e := range r.Errors {
+		errs[i] = e
+	}
+	return errors.Join(errs...)
+}
+
+// diffSets compares current and desired item sets, returning sorted lists of
+// the items to add, to remove, and left unchanged. Duplicates are ignored.
+func diffSets(current, desired []string) (add, remove, unchanged []string) {
+	have := make(map[string]bool, len(current))
+	for _, item := range current {
+		have[item] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		want[item] = true
+	}
+	for item := range want {
+		if have[item] {
+			unchanged = append(unchanged, item)
+		} else {
+			add = append(add, item)
+		}
+	}
+	for item := range have {
+		if !want[item] {
+			remove = append(remove, item)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	sort.Strings(unchanged)
+	return add, remove, unchanged
+}
+
+// reconcile applies a diff of current against desired with the given add and
+// remove functions, recording per-item failures instead of stopping. It stops
+// early only if ctx is done.
+func reconcile(ctx context.Context, current, desired []string, addItem, removeItem func(item string) error) (*ReconcileResult, error) {
+	add, remove, unchanged := diffSets(current, desired)
+	result := &ReconcileResult{Unchanged: unchanged}
+	for _, item := range add {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := addItem(item); err != nil {
+			result.Errors = append(result.Errors, ReconcileError{Item: item, Err: err})
+			continue
+		}
+		result.Added = append(result.Added, item)
+	}
+	for _, item := range remove {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := removeItem(item); err != nil {
+			result.Errors = append(result.Errors, ReconcileError{Item: item, Err: err})
+			continue
+		}
+		result.Removed = append(result.Removed, item)
+	}
+	return result, nil
+}
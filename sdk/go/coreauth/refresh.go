@@ -0,0 +1,207 @@
+package coreauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// refreshCall is an in-flight token refresh shared by concurrent requests.
+type refreshCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+func (c *httpClient) setRefreshToken(refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshToken = refreshToken
+	c.refreshErr = nil
+}
+
+// refreshableCodes are the 401 error codes that report an expired or invalid
+// access token. Other 401s, such as login_failed or invalid_password, are
+// about the request's credentials and are not helped by a refresh.
+var refreshableCodes = map[string]bool{
+	CodeInvalidToken: true,
+	CodeTokenExpired: true,
+	CodeExpiredToken: true,
+}
+
+// credentialPaths are the endpoints that check credentials sent in the
+// request body; a 401 from them never triggers a refresh.
+var credentialPaths = map[string]bool{
+	"/api/auth/login":              true,
+	"/api/auth/login-hierarchical": true,
+	"/api/auth/change-password":    true,
+	"/api/auth/refresh":            true,
+	"/oauth/token":                 true,
+}
+
+// isCredentialPath reports whether path is a credential endpoint.
+func isCredentialPath(path string) bool {
+	return credentialPaths[path] || strings.HasPrefix(path, "/api/mfa/verify-with-token/")
+}
+
+// refreshMiddleware refreshes the access token and replays the request once
+// when a request authenticated with the client's token gets a 401 reporting
+// an expired or invalid token. Requests whose Authorization header was set
+// by the caller, requests to credential endpoints such as login and change
+// password, and requests whose body cannot be replayed, are returned
+// unchanged.
+func (c *httpClient) refreshMiddleware(next RoundTripper) RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		c.mu.Lock()
+		enabled := c.refreshToken != "" || c.refreshErr != nil
+		stale := c.token
+		c.mu.Unlock()
+		if !enabled || req.Header.Get("Authorization") != "" || isRefreshRequest(req.Context()) || isCredentialPath(req.URL.Path) {
+			return next.RoundTrip(req)
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !refreshableCodes[peekErrorCode(resp)] {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+		c.emitAuthEvent(AuthEventUnauthorized, stale, nil)
+		token, err := c.refreshAccessToken(req.Context(), stale)
+		if errors.Is(err, ErrRefreshTokenExpired) || errors.Is(err, ErrRefreshTokenReuse) {
+			resp.Body.Close()
+			return nil, err
+		}
+		if err != nil || token == "" {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		retry.Header.Set("Authorization", "Bearer "+token)
+		return next.RoundTrip(retry)
+	})
+}
+
+// peekErrorCode returns the error code of a JSON error response, leaving
+// the body in place to be read again.
+func peekErrorCode(resp *http.Response) string {
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+	peek := *resp
+	peek.Body = io.NopCloser(bytes.NewReader(b))
+	body, err := decodedBody(&peek)
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	var errBody struct {
+		Error string `json:"error"`
+	}
+	if json.NewDecoder(body).Decode(&errBody) != nil {
+		return ""
+	}
+	return errBody.Error
+}
+
+// refreshAccessToken returns a fresh access token, refreshing it if the
+// current token is still stale. Only one refresh runs at a time; concurrent
+// callers wait for it and share its outcome. A rejected refresh token is
+// terminal: it is recorded so later callers fail with ErrRefreshTokenExpired,
+// or ErrRefreshTokenReuse if the server detected reuse, without contacting
+// the server.
+func (c *httpClient) refreshAccessToken(ctx context.Context, stale string) (string, error) {
+	c.mu.Lock()
+	if c.refreshErr != nil {
+		err := c.refreshErr
+		c.mu.Unlock()
+		return "", err
+	}
+	if c.token != stale && c.token != "" {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	if call := c.refreshing; call != nil {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	if c.refreshToken == "" {
+		c.mu.Unlock()
+		return "", nil
+	}
+	call := &refreshCall{done: make(chan struct{})}
+	c.refreshing = call
+	refreshToken := c.refreshToken
+	c.mu.Unlock()
+
+	// The refresh is shared, so it must not be cut short by the context of
+	// whichever request happened to start it.
+	resp, err := c.postRefresh(context.WithoutCancel(ctx), refreshToken)
+
+	c.mu.Lock()
+	c.refreshing = nil
+	var apiErr *ApiError
+	switch {
+	case err == nil:
+		call.token = resp.AccessToken
+		c.token = resp.AccessToken
+		if resp.RefreshToken != "" {
+			c.refreshToken = resp.RefreshToken
+		}
+	case errors.Is(err, ErrRefreshTokenReuse):
+		call.err = err
+		c.refreshErr = call.err
+		c.refreshToken = ""
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == 400 || apiErr.StatusCode == 401 || apiErr.StatusCode == 403):
+		call.err = fmt.Errorf("%w: %w", ErrRefreshTokenExpired, err)
+		c.refreshErr = call.err
+		c.refreshToken = ""
+	default:
+		call.err = err
+	}
+	c.mu.Unlock()
+	close(call.done)
+	if call.err != nil {
+		c.emitAuthEvent(AuthEventRefreshFailed, stale, call.err)
+	} else {
+		c.emitAuthEvent(AuthEventRefresh, call.token, nil)
+	}
+	return call.token, call.err
+}
+
+// postRefresh exchanges a refresh token for a new token pair.
+func (c *httpClient) postRefresh(ctx context.Context, refreshToken string) (*AuthResponse, error) {
+	raw, err := c.post(withRefreshRequest(ctx), "/api/auth/refresh", RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	var resp AuthResponse
+	if err := decodeJSON(raw, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, &CoreAuthError{Message: "refresh response did not include an access token"}
+	}
+	return &resp, nil
+}
@@ -0,0 +1,236 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// conflictRetryDelay is the mean wait before retrying a conflicted request;
+// each wait is randomized between half and one and a half times this value,
+// scaled by the attempt number.
+const conflictRetryDelay = 100 * time.Millisecond
+
+// WithConflictRetry retries conflict-prone operations up to maxAttempts
+// times in total when the server answers 409 Conflict, after a short
+// randomized delay. Only operations whose conflicts are transient are
+// retried: secret rotation (ApplicationsService.RotateSecret and
+// RotateOAuthSecret, WebhooksService.RotateSecret) and
+// FgaService.WriteModel. Other 409s, such as a duplicate slug, are returned
+// immediately. maxAttempts below 2 disables retrying.
+func WithConflictRetry(maxAttempts int) Option {
+	return func(c *Client) {
+		c.http.conflictAttempts = maxAttempts
+	}
+}
+
+// WithRetry retries calls that fail transiently, up to maxAttempts times in
+// total, waiting baseDelay before the second attempt and doubling the wait
+// after each one, with random jitter; WithRetrySchedule replaces this
+// schedule. Idempotent calls (GET, PUT, DELETE) are retried on 502, 503,
+// and 504 responses and on temporary network errors. Other calls, such as
+// POST, are retried only when the connection could not be established, so
+// the request was never sent: the server does not deduplicate on
+// Idempotency-Key, and a POST resent after a timeout or a reset connection
+// could take effect twice. A call whose body cannot be replayed is not
+// retried. When the server advertises a wait with Retry-After, as it may
+// for a 503 reporting maintenance, the next attempt waits at least that
+// long.
+//
+// Retrying stops when ctx is done or when the next wait would run past its
+// deadline. A call that failed after several attempts returns a
+// *RetryError wrapping the last attempt's error. maxAttempts below 2
+// disables retrying.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.http.retryAttempts = maxAttempts
+		c.http.retryDelay = baseDelay
+	}
+}
+
+// doWithRetry is do under WithRetry.
+func (c *httpClient) doWithRetry(ctx context.Context, method, path string, body io.Reader, contentType string) (*Response, error) {
+	seeker, replayable := body.(io.Seeker)
+	if body != nil && !replayable {
+		resp, err := c.execute(ctx, method, path, body, contentType)
+		if err != nil {
+			return nil, err
+		}
+		return readResponse(resp)
+	}
+	ctx = c.withGeneratedIDs(ctx, method)
+	start := time.Now()
+	var last *Response
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && seeker != nil {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+		resp, retryable, err := c.attempt(withAttempt(ctx, attempt, start), method, path, body, contentType)
+		if err == nil {
+			return resp, nil
+		}
+		last = resp
+		errs = append(errs, err)
+		if !retryable || attempt >= c.retryAttempts {
+			break
+		}
+		delay := max(c.retryWait(attempt), retryAfter(err))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			break
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			errs = append(errs, err)
+			break
+		}
+	}
+	if len(errs) == 1 {
+		return last, errs[0]
+	}
+	return last, &RetryError{Err: errs[len(errs)-1], Attempts: errs}
+}
+
+// attempt makes a single attempt of a call and reports whether a failure
+// may be retried.
+func (c *httpClient) attempt(ctx context.Context, method, path string, body io.Reader, contentType string) (*Response, bool, error) {
+	resp, err := c.execute(ctx, method, path, body, contentType)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, false, err
+		}
+		if isIdempotent(method) {
+			return nil, isTemporaryNetError(err), err
+		}
+		// The request may have reached the server unless the connection
+		// itself failed.
+		return nil, isDialError(err), err
+	}
+	r, err := readResponse(resp)
+	if err == nil || !isIdempotent(method) || ctx.Err() != nil {
+		return r, false, err
+	}
+	var maintErr *MaintenanceError
+	var apiErr *ApiError
+	switch {
+	case errors.As(err, &maintErr):
+		return r, true, err
+	case errors.As(err, &apiErr):
+		s := apiErr.StatusCode
+		return r, s == http.StatusBadGateway || s == http.StatusServiceUnavailable || s == http.StatusGatewayTimeout, err
+	}
+	return r, isTemporaryNetError(err), err
+}
+
+// retryAfter returns the wait the server advertised with a failed call, or
+// zero if it gave none.
+func retryAfter(err error) time.Duration {
+	var maintErr *MaintenanceError
+	if errors.As(err, &maintErr) && maintErr.RetryAfter > 0 {
+		return maintErr.RetryAfter
+	}
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// sleepContext waits for d, returning ctx's error if it is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTemporaryNetError reports whether err is a network failure worth
+// retrying: a timeout, a refused or reset connection, or a connection
+// closed mid-response.
+func isTemporaryNetError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// isDialError reports whether err is a failure to establish the connection,
+// such as a refused connection or a failed DNS lookup, so no part of the
+// request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// conflictRetryMiddleware replays conflict-retryable requests that got a
+// 409, stamping each replay's context with its attempt number.
+func (c *httpClient) conflictRetryMiddleware(next RoundTripper) RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if !isConflictRetryable(req.Context()) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		first, start := attemptStart(req)
+		for attempt := first + 1; attempt <= c.conflictAttempts; attempt++ {
+			if err != nil || resp.StatusCode != http.StatusConflict {
+				break
+			}
+			jitter := time.Duration(rand.Int63n(int64(conflictRetryDelay)))
+			delay := time.Duration(attempt-first) * (conflictRetryDelay/2 + jitter)
+			timer := time.NewTimer(delay)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return resp, err
+			case <-timer.C:
+			}
+			resp.Body.Close()
+
+			retry := req.Clone(withAttempt(req.Context(), attempt, start))
+			if req.GetBody != nil {
+				if retry.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			resp, err = next.RoundTrip(retry)
+		}
+		return resp, err
+	})
+}
+
+// attemptStart returns the attempt number and start time recorded on req's
+// context, defaulting to a first attempt starting now.
+func attemptStart(req *http.Request) (int, time.Time) {
+	n, elapsed, ok := AttemptInfo(req.Context())
+	if !ok {
+		return 1, time.Now()
+	}
+	return n, time.Now().Add(-elapsed)
+}
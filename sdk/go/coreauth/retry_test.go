@@ -0,0 +1,67 @@
+package coreauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newUnavailableServer returns a server that answers every request with a
+// 503 and counts the requests.
+func newUnavailableServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"unavailable","message":"try again"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRetryGivesUpBeforeDeadline(t *testing.T) {
+	checkGoroutines(t)
+	var hits atomic.Int32
+	c := newTestClient(t, newUnavailableServer(t, &hits), WithRetry(5, 10*time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Auth.GetProfile(ctx)
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("GetProfile returned after %v, want it to give up without waiting", elapsed)
+	}
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("GetProfile error = %v, want the 503", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server got %d requests, want 1", n)
+	}
+}
+
+func TestRetryWaitsStayWithinDeadline(t *testing.T) {
+	checkGoroutines(t)
+	var hits atomic.Int32
+	c := newTestClient(t, newUnavailableServer(t, &hits), WithRetry(10, 20*time.Millisecond))
+
+	const timeout = 300 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Auth.GetProfile(ctx)
+	if elapsed := time.Since(start); elapsed >= timeout {
+		t.Errorf("GetProfile returned after %v, past the %v deadline", elapsed, timeout)
+	}
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("GetProfile error = %v, want a *RetryError", err)
+	}
+	if n := hits.Load(); n < 2 || int(n) != len(retryErr.Attempts) {
+		t.Errorf("server got %d requests for %d recorded attempts, want at least 2", n, len(retryErr.Attempts))
+	}
+}
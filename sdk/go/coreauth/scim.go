@@ -1,9 +1,15 @@
 package coreauth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ScimService provides SCIM 2.0 provisioning, session management, and OIDC provider operations.
@@ -18,43 +24,165 @@ func (s *ScimService) GetConfig(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/scim/v2/ServiceProviderConfig", nil)
 }
 
+// GetConfigInto is like GetConfig but decodes the response into out.
+func (s *ScimService) GetConfigInto(ctx context.Context, out any) error {
+	raw, err := s.GetConfig(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetResourceTypes retrieves the SCIM resource type definitions.
 func (s *ScimService) GetResourceTypes(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/scim/v2/ResourceTypes", nil)
 }
 
+// GetResourceTypesInto is like GetResourceTypes but decodes the response into out.
+func (s *ScimService) GetResourceTypesInto(ctx context.Context, out any) error {
+	raw, err := s.GetResourceTypes(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetSchemas retrieves the SCIM schema definitions.
 func (s *ScimService) GetSchemas(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/scim/v2/Schemas", nil)
 }
 
+// GetSchemasInto is like GetSchemas but decodes the response into out.
+func (s *ScimService) GetSchemasInto(ctx context.Context, out any) error {
+	raw, err := s.GetSchemas(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// RawRequest sends arbitrary SCIM JSON for extensions the typed helpers do
+// not cover, such as the enterprise user schema or custom attributes. path is
+// relative to the SCIM base (e.g. "/Users/123"); a path already starting with
+// "/scim/" is used as is. body may be nil. The request goes through the same
+// pipeline as every other call, including auth and error parsing.
+func (s *ScimService) RawRequest(ctx context.Context, method, path string, body json.RawMessage) (json.RawMessage, error) {
+	if !strings.HasPrefix(path, "/scim/") {
+		path = "/scim/v2/" + strings.TrimPrefix(path, "/")
+	}
+	var r io.Reader
+	if len(body) > 0 {
+		r = bytes.NewReader(body)
+	}
+	return s.http.doRequest(ctx, strings.ToUpper(method), path, r, "application/scim+json")
+}
+
+// RawRequestInto is like RawRequest but decodes the response into out.
+func (s *ScimService) RawRequestInto(ctx context.Context, method, path string, body json.RawMessage, out any) error {
+	raw, err := s.RawRequest(ctx, method, path, body)
+	return decodeResult(raw, err, out)
+}
+
 // --- SCIM Users ---
 
-// ListUsers returns SCIM users with optional filtering.
+// ListUsers returns SCIM users with optional filtering. Build the "filter"
+// param with ScimFilter rather than by hand.
 func (s *ScimService) ListUsers(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/scim/v2/Users", params)
 }
 
+// ListUsersInto is like ListUsers but decodes the response into out.
+func (s *ScimService) ListUsersInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.ListUsers(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
+// UsersPaginator returns a Paginator over SCIM users matching filter (a SCIM
+// filter expression, or "" for all users), requesting count users per page.
+// SCIM's startIndex is 1-based; the paginator handles the conversion.
+func (s *ScimService) UsersPaginator(filter string, count int) *Paginator[ScimUser] {
+	return NewPaginator(scimPageFunc[ScimUser](filter, count, s.ListUsersInto))
+}
+
+// ListAllUsers returns every SCIM user matching filter, following
+// pagination.
+func (s *ScimService) ListAllUsers(ctx context.Context, filter string) ([]ScimUser, error) {
+	return s.UsersPaginator(filter, defaultPageSize).All(ctx)
+}
+
 // CreateUser provisions a new user via SCIM.
 func (s *ScimService) CreateUser(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/scim/v2/Users", data)
 }
 
+// CreateUserInto is like CreateUser but decodes the response into out.
+func (s *ScimService) CreateUserInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateUser(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // GetUser retrieves a SCIM user by ID.
 func (s *ScimService) GetUser(ctx context.Context, userID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/scim/v2/Users/%s", userID), nil)
 }
 
+// GetUserInto is like GetUser but decodes the response into out.
+func (s *ScimService) GetUserInto(ctx context.Context, userID string, out any) error {
+	raw, err := s.GetUser(ctx, userID)
+	return decodeResult(raw, err, out)
+}
+
 // ReplaceUser fully replaces a SCIM user (PUT).
 func (s *ScimService) ReplaceUser(ctx context.Context, userID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/scim/v2/Users/%s", userID), data)
 }
 
+// ReplaceUserInto is like ReplaceUser but decodes the response into out.
+func (s *ScimService) ReplaceUserInto(ctx context.Context, userID string, data map[string]any, out any) error {
+	raw, err := s.ReplaceUser(ctx, userID, data)
+	return decodeResult(raw, err, out)
+}
+
 // PatchUser partially updates a SCIM user (PATCH).
 func (s *ScimService) PatchUser(ctx context.Context, userID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.patch(ctx, fmt.Sprintf("/scim/v2/Users/%s", userID), data)
 }
 
+// PatchUserInto is like PatchUser but decodes the response into out.
+func (s *ScimService) PatchUserInto(ctx context.Context, userID string, data map[string]any, out any) error {
+	raw, err := s.PatchUser(ctx, userID, data)
+	return decodeResult(raw, err, out)
+}
+
+// ReplaceUserTyped fully replaces a SCIM user (PUT) and returns the updated
+// resource. A PUT must carry the whole resource, so data is checked for a
+// userName first and a *ValidationError is returned without sending if it is
+// missing. If the server answers 204 No Content the user is fetched again.
+func (s *ScimService) ReplaceUserTyped(ctx context.Context, userID string, data map[string]any) (*ScimUser, error) {
+	if name, _ := data["userName"].(string); name == "" {
+		return nil, &ValidationError{Field: "userName", Message: "is required when replacing a SCIM user"}
+	}
+	raw, err := s.ReplaceUser(ctx, userID, data)
+	return s.updatedUser(ctx, userID, raw, err)
+}
+
+// PatchUserTyped partially updates a SCIM user (PATCH) and returns the
+// updated resource. If the server answers 204 No Content the user is fetched
+// again.
+func (s *ScimService) PatchUserTyped(ctx context.Context, userID string, data map[string]any) (*ScimUser, error) {
+	raw, err := s.PatchUser(ctx, userID, data)
+	return s.updatedUser(ctx, userID, raw, err)
+}
+
+// updatedUser decodes the response of a user update, re-fetching the user
+// when the response had no body.
+func (s *ScimService) updatedUser(ctx context.Context, userID string, raw json.RawMessage, err error) (*ScimUser, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		if raw, err = s.GetUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	var user ScimUser
+	if err := decodeJSON(raw, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // DeleteUser deprovisions a SCIM user.
 func (s *ScimService) DeleteUser(ctx context.Context, userID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/scim/v2/Users/%s", userID), nil)
@@ -63,26 +191,114 @@ func (s *ScimService) DeleteUser(ctx context.Context, userID string) error {
 
 // --- SCIM Groups ---
 
-// ListScimGroups returns SCIM groups with optional filtering.
+// ListScimGroups returns SCIM groups with optional filtering. Build the
+// "filter" param with ScimFilter rather than by hand.
 func (s *ScimService) ListScimGroups(ctx context.Context, params map[string]string) (json.RawMessage, error) {
 	return s.http.get(ctx, "/scim/v2/Groups", params)
 }
 
+// ListScimGroupsInto is like ListScimGroups but decodes the response into out.
+func (s *ScimService) ListScimGroupsInto(ctx context.Context, params map[string]string, out any) error {
+	raw, err := s.ListScimGroups(ctx, params)
+	return decodeResult(raw, err, out)
+}
+
+// GroupsPaginator returns a Paginator over SCIM groups matching filter (a
+// SCIM filter expression, or "" for all groups), requesting count groups per
+// page. SCIM's startIndex is 1-based; the paginator handles the conversion.
+func (s *ScimService) GroupsPaginator(filter string, count int) *Paginator[ScimGroup] {
+	return NewPaginator(scimPageFunc[ScimGroup](filter, count, s.ListScimGroupsInto))
+}
+
+// ListAllGroups returns every SCIM group matching filter, following
+// pagination.
+func (s *ScimService) ListAllGroups(ctx context.Context, filter string) ([]ScimGroup, error) {
+	return s.GroupsPaginator(filter, defaultPageSize).All(ctx)
+}
+
+// scimPageFunc adapts a SCIM list endpoint to a PageFunc whose cursor is the
+// 1-based startIndex of the page. Each page advances startIndex by the
+// page's itemsPerPage, which may be less than count if the server caps page
+// sizes, until startIndex+itemsPerPage exceeds totalResults.
+func scimPageFunc[T any](filter string, count int, list func(ctx context.Context, params map[string]string, out any) error) PageFunc[T] {
+	if count <= 0 {
+		count = defaultPageSize
+	}
+	return func(ctx context.Context, cursor string) ([]T, string, error) {
+		start := 1
+		if cursor != "" {
+			var err error
+			if start, err = strconv.Atoi(cursor); err != nil || start < 1 {
+				return nil, "", &CoreAuthError{Message: fmt.Sprintf("invalid page cursor %q", cursor)}
+			}
+		}
+		params := map[string]string{
+			"startIndex": strconv.Itoa(start),
+			"count":      strconv.Itoa(count),
+			"filter":     filter,
+		}
+		var page struct {
+			TotalResults int `json:"totalResults"`
+			ItemsPerPage int `json:"itemsPerPage"`
+			Resources    []T `json:"Resources"`
+		}
+		if err := list(ctx, params, &page); err != nil {
+			return nil, "", err
+		}
+		perPage := page.ItemsPerPage
+		if perPage <= 0 || perPage > len(page.Resources) {
+			perPage = len(page.Resources)
+		}
+		next := start + perPage
+		if perPage == 0 || next > page.TotalResults {
+			return page.Resources, "", nil
+		}
+		return page.Resources, strconv.Itoa(next), nil
+	}
+}
+
 // CreateScimGroup creates a new SCIM group.
 func (s *ScimService) CreateScimGroup(ctx context.Context, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, "/scim/v2/Groups", data)
 }
 
+// CreateScimGroupInto is like CreateScimGroup but decodes the response into out.
+func (s *ScimService) CreateScimGroupInto(ctx context.Context, data map[string]any, out any) error {
+	raw, err := s.CreateScimGroup(ctx, data)
+	return decodeResult(raw, err, out)
+}
+
 // GetScimGroup retrieves a SCIM group by ID.
 func (s *ScimService) GetScimGroup(ctx context.Context, groupID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/scim/v2/Groups/%s", groupID), nil)
 }
 
+// GetScimGroupInto is like GetScimGroup but decodes the response into out.
+func (s *ScimService) GetScimGroupInto(ctx context.Context, groupID string, out any) error {
+	raw, err := s.GetScimGroup(ctx, groupID)
+	return decodeResult(raw, err, out)
+}
+
+// GetScimGroupTyped retrieves a SCIM group by ID.
+func (s *ScimService) GetScimGroupTyped(ctx context.Context, groupID string) (*ScimGroup, error) {
+	var group ScimGroup
+	if err := s.GetScimGroupInto(ctx, groupID, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
 // PatchScimGroup partially updates a SCIM group.
 func (s *ScimService) PatchScimGroup(ctx context.Context, groupID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.patch(ctx, fmt.Sprintf("/scim/v2/Groups/%s", groupID), data)
 }
 
+// PatchScimGroupInto is like PatchScimGroup but decodes the response into out.
+func (s *ScimService) PatchScimGroupInto(ctx context.Context, groupID string, data map[string]any, out any) error {
+	raw, err := s.PatchScimGroup(ctx, groupID, data)
+	return decodeResult(raw, err, out)
+}
+
 // DeleteScimGroup removes a SCIM group.
 func (s *ScimService) DeleteScimGroup(ctx context.Context, groupID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/scim/v2/Groups/%s", groupID), nil)
@@ -96,22 +312,155 @@ func (s *ScimService) ListScimTokens(ctx context.Context, orgID string) (json.Ra
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/scim/tokens", orgID), nil)
 }
 
+// ListScimTokensInto is like ListScimTokens but decodes the response into out.
+func (s *ScimService) ListScimTokensInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.ListScimTokens(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // CreateScimToken creates a new SCIM bearer token for an organization.
 func (s *ScimService) CreateScimToken(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/scim/tokens", orgID), data)
 }
 
+// CreateScimTokenInto is like CreateScimToken but decodes the response into out.
+func (s *ScimService) CreateScimTokenInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.CreateScimToken(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
+// ListScimTokensTyped returns all SCIM bearer tokens for an organization.
+func (s *ScimService) ListScimTokensTyped(ctx context.Context, orgID string) ([]ScimTokenResponse, error) {
+	tokens := []ScimTokenResponse{}
+	if err := s.ListScimTokensInto(ctx, orgID, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// CreateScimTokenTyped creates a new SCIM bearer token for an organization.
+// The returned secret is only available at creation time.
+func (s *ScimService) CreateScimTokenTyped(ctx context.Context, orgID string, req CreateScimTokenRequest) (*ScimTokenWithSecret, error) {
+	raw, err := s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/scim/tokens", orgID), req)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		ScimTokenWithSecret
+		Token string `json:"token"`
+	}
+	if err := decodeJSON(raw, &out); err != nil {
+		return nil, err
+	}
+	if out.Secret == "" {
+		out.Secret = out.Token
+	}
+	return &out.ScimTokenWithSecret, nil
+}
+
 // RevokeScimToken revokes a SCIM bearer token.
 func (s *ScimService) RevokeScimToken(ctx context.Context, orgID, tokenID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/scim/tokens/%s", orgID, tokenID), nil)
 	return err
 }
 
+// RevokeTokensOlderThan revokes every SCIM token of an organization created
+// before cutoff and returns how many were revoked. Tokens without a
+// parseable creation time are skipped with a warning to the client's Logger.
+// It keeps going after a failed revoke; failures are returned together,
+// joined with errors.Join, and each names its token.
+func (s *ScimService) RevokeTokensOlderThan(ctx context.Context, orgID string, cutoff time.Time) (int, error) {
+	tokens, err := s.ListScimTokensTyped(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	revoked := 0
+	var errs []error
+	for _, t := range tokens {
+		created := t.CreatedAtTime()
+		if created.IsZero() {
+			s.http.warnf(ctx, "skipping SCIM token %s (%s): no parseable created_at", t.ID, t.Name)
+			continue
+		}
+		if !created.Before(cutoff) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := s.RevokeScimToken(ctx, orgID, t.ID); err != nil {
+			errs = append(errs, fmt.Errorf("token %s: %w", t.ID, err))
+			continue
+		}
+		revoked++
+	}
+	return revoked, errors.Join(errs...)
+}
+
 // --- Sessions ---
 
-// ListSessions returns all active sessions for the authenticated user.
+// ListSessions returns all active sessions for the authenticated user. The
+// server requires the user's ID, which is taken from the sub claim of the
+// stored token; without one the server rejects the request.
 func (s *ScimService) ListSessions(ctx context.Context) (json.RawMessage, error) {
-	return s.http.get(ctx, "/api/sessions", nil)
+	return s.http.get(ctx, "/api/sessions", map[string]string{"user_id": s.http.tokenSubject()})
+}
+
+// ListSessionsInto is like ListSessions but decodes the response into out.
+func (s *ScimService) ListSessionsInto(ctx context.Context, out any) error {
+	raw, err := s.ListSessions(ctx)
+	return decodeResult(raw, err, out)
+}
+
+// ListSessionsTyped is like ListSessions but decodes the sessions. IsCurrent
+// is taken from the server as is: a bearer token names no session, so the
+// SDK cannot work out which session is the caller's, and the server does
+// not currently flag it either.
+func (s *ScimService) ListSessionsTyped(ctx context.Context) ([]SessionInfo, error) {
+	var sessions []SessionInfo
+	if err := s.ListSessionsInto(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeOtherSessions revokes every session of the authenticated user
+// except currentSessionID and returns how many the server revoked. The
+// current session cannot be identified from a bearer token, so the caller
+// must name it, for example from its own session cookie. If currentSessionID
+// is empty, the session the server flags with IsCurrent is kept; if there is
+// none, nothing is revoked and a *ValidationError is returned, so the caller
+// is never signed out by accident. The user's ID is taken from the sub claim
+// of the stored token.
+func (s *ScimService) RevokeOtherSessions(ctx context.Context, currentSessionID string) (int, error) {
+	if currentSessionID == "" {
+		sessions, err := s.ListSessionsTyped(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, sess := range sessions {
+			if sess.IsCurrent {
+				currentSessionID = sess.ID
+				break
+			}
+		}
+		if currentSessionID == "" {
+			return 0, &ValidationError{Field: "except_session_id", Message: "the current session cannot be identified; no sessions revoked"}
+		}
+	}
+	q := queryValues(map[string]string{"user_id": s.http.tokenSubject(), "except_session_id": currentSessionID})
+	raw, err := s.http.post(ctx, "/api/sessions/revoke-all?"+q.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		RevokedCount int `json:"revoked_count"`
+	}
+	if err := decodeJSON(raw, &result); err != nil {
+		return 0, err
+	}
+	return result.RevokedCount, nil
 }
 
 // RevokeSession revokes a specific session by ID.
@@ -120,6 +469,34 @@ func (s *ScimService) RevokeSession(ctx context.Context, sessionID string) error
 	return err
 }
 
+// RevokeSessionChecked revokes a session for a "sign out this device" flow
+// and confirms the result. It is idempotent: a session that is already gone,
+// such as one that expired concurrently, counts as revoked and returns nil
+// rather than an error matching ErrSessionNotFound. After revoking, it
+// lists the sessions and returns ErrSessionStillActive if the session is
+// still among them. If the sessions cannot be listed, the revocation the
+// server confirmed is trusted and a warning is logged.
+func (s *ScimService) RevokeSessionChecked(ctx context.Context, sessionID string) error {
+	err := s.RevokeSession(ctx, sessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var sessions []SessionInfo
+	if err := s.ListSessionsInto(ctx, &sessions); err != nil {
+		s.http.warnf(ctx, "could not verify revocation of session %s: %v", sessionID, err)
+		return nil
+	}
+	for _, sess := range sessions {
+		if sess.ID == sessionID {
+			return fmt.Errorf("session %s: %w", sessionID, ErrSessionStillActive)
+		}
+	}
+	return nil
+}
+
 // RevokeAllSessions revokes all sessions for the authenticated user.
 func (s *ScimService) RevokeAllSessions(ctx context.Context) error {
 	_, err := s.http.del(ctx, "/api/sessions", nil)
@@ -133,16 +510,48 @@ func (s *ScimService) ListOidcProviders(ctx context.Context, orgID string) (json
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/oidc-providers", orgID), nil)
 }
 
+// ListOidcProvidersInto is like ListOidcProviders but decodes the response into out.
+func (s *ScimService) ListOidcProvidersInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.ListOidcProviders(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // CreateOidcProvider configures a new OIDC provider for an organization.
 func (s *ScimService) CreateOidcProvider(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/oidc-providers", orgID), data)
 }
 
+// CreateOidcProviderInto is like CreateOidcProvider but decodes the response into out.
+func (s *ScimService) CreateOidcProviderInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.CreateOidcProvider(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateOidcProvider updates an OIDC provider configuration.
 func (s *ScimService) UpdateOidcProvider(ctx context.Context, orgID, providerID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/oidc-providers/%s", orgID, providerID), data)
 }
 
+// UpdateOidcProviderInto is like UpdateOidcProvider but decodes the response into out.
+func (s *ScimService) UpdateOidcProviderInto(ctx context.Context, orgID, providerID string, data map[string]any, out any) error {
+	raw, err := s.UpdateOidcProvider(ctx, orgID, providerID, data)
+	return decodeResult(raw, err, out)
+}
+
+// UpdateOidcProviderTyped enables or disables an OIDC provider of the
+// caller's organization and returns the updated provider. Enabling is the
+// only change the server supports; the rest of the provider's configuration
+// cannot be updated, and the returned provider carries only its ID, name,
+// type, enabled flag and allowed group.
+func (s *ScimService) UpdateOidcProviderTyped(ctx context.Context, providerID string, req OidcProviderToggleRequest) (*OidcProvider, error) {
+	raw, err := s.http.patch(ctx, fmt.Sprintf("/api/oidc/providers/%s", providerID), req)
+	var provider OidcProvider
+	if err := decodeResult(raw, err, &provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
 // DeleteOidcProvider removes an OIDC provider configuration.
 func (s *ScimService) DeleteOidcProvider(ctx context.Context, orgID, providerID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/oidc-providers/%s", orgID, providerID), nil)
@@ -154,17 +563,58 @@ func (s *ScimService) ListPublicProviders(ctx context.Context, orgSlug string) (
 	return s.http.get(ctx, fmt.Sprintf("/api/public/oidc-providers/%s", orgSlug), nil)
 }
 
+// ListPublicProvidersInto is like ListPublicProviders but decodes the response into out.
+func (s *ScimService) ListPublicProvidersInto(ctx context.Context, orgSlug string, out any) error {
+	raw, err := s.ListPublicProviders(ctx, orgSlug)
+	return decodeResult(raw, err, out)
+}
+
 // ListProviderTemplates returns the available OIDC provider templates.
 func (s *ScimService) ListProviderTemplates(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/oidc-providers/templates", nil)
 }
 
+// ListProviderTemplatesInto is like ListProviderTemplates but decodes the response into out.
+func (s *ScimService) ListProviderTemplatesInto(ctx context.Context, out any) error {
+	raw, err := s.ListProviderTemplates(ctx)
+	return decodeResult(raw, err, out)
+}
+
 // GetProviderTemplate retrieves a specific OIDC provider template.
 func (s *ScimService) GetProviderTemplate(ctx context.Context, templateName string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/oidc-providers/templates/%s", templateName), nil)
 }
 
+// GetProviderTemplateInto is like GetProviderTemplate but decodes the response into out.
+func (s *ScimService) GetProviderTemplateInto(ctx context.Context, templateName string, out any) error {
+	raw, err := s.GetProviderTemplate(ctx, templateName)
+	return decodeResult(raw, err, out)
+}
+
 // SSOCheck checks if an email domain has SSO configured and returns the provider details.
 func (s *ScimService) SSOCheck(ctx context.Context, email string) (json.RawMessage, error) {
-	return s.http.get(ctx, "/api/sso/check", map[string]string{"email": email})
+	return s.http.get(ctx, "/api/oidc/sso-check", map[string]string{"email": email})
+}
+
+// SSOCheckInto is like SSOCheck but decodes the response into out.
+func (s *ScimService) SSOCheckInto(ctx context.Context, email string, out any) error {
+	raw, err := s.SSOCheck(ctx, email)
+	return decodeResult(raw, err, out)
+}
+
+// OidcLogin starts a login with an organization's OIDC provider. The
+// response holds the authorization_url to send the user to; the provider
+// returns them to redirectURI.
+func (s *ScimService) OidcLogin(ctx context.Context, tenantID, providerID, redirectURI string) (json.RawMessage, error) {
+	return s.http.get(ctx, "/api/oidc/login", map[string]string{
+		"tenant_id":    tenantID,
+		"provider_id":  providerID,
+		"redirect_uri": redirectURI,
+	})
+}
+
+// OidcLoginInto is like OidcLogin but decodes the response into out.
+func (s *ScimService) OidcLoginInto(ctx context.Context, tenantID, providerID, redirectURI string, out any) error {
+	raw, err := s.OidcLogin(ctx, tenantID, providerID, redirectURI)
+	return decodeResult(raw, err, out)
 }
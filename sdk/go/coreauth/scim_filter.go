@@ -0,0 +1,92 @@
+package coreauth
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ScimFilter builds a SCIM filter expression (RFC 7644, section 3.4.2.2)
+// with every value quoted and escaped, so values containing quotes or
+// backslashes cannot break out of the expression. The zero value is an
+// empty filter; comparisons added to a filter are joined with "and":
+//
+//	f := coreauth.ScimFilter{}.Eq("userName", email).Sw("name.familyName", "O'")
+//	users, err := client.Scim.ListAllUsers(ctx, f.String())
+//
+// Pass the result to ListUsers or ListScimGroups as the "filter" param.
+type ScimFilter struct {
+	expr string
+	// or records whether expr is an "or" at the top level, which must be
+	// parenthesized when joined with "and".
+	or bool
+}
+
+// Eq adds the comparison attr eq value.
+func (f ScimFilter) Eq(attr, value string) ScimFilter {
+	return f.And(scimCompare(attr, "eq", value))
+}
+
+// Co adds the comparison attr co value, matching attributes that contain
+// value.
+func (f ScimFilter) Co(attr, value string) ScimFilter {
+	return f.And(scimCompare(attr, "co", value))
+}
+
+// Sw adds the comparison attr sw value, matching attributes that start with
+// value.
+func (f ScimFilter) Sw(attr, value string) ScimFilter {
+	return f.And(scimCompare(attr, "sw", value))
+}
+
+// And returns a filter matching resources that match both f and other.
+// Either filter may be empty.
+func (f ScimFilter) And(other ScimFilter) ScimFilter {
+	switch {
+	case f.expr == "":
+		return other
+	case other.expr == "":
+		return f
+	}
+	return ScimFilter{expr: f.grouped() + " and " + other.grouped()}
+}
+
+// Or returns a filter matching resources that match f or other. Either
+// filter may be empty.
+func (f ScimFilter) Or(other ScimFilter) ScimFilter {
+	switch {
+	case f.expr == "":
+		return other
+	case other.expr == "":
+		return f
+	}
+	return ScimFilter{expr: f.expr + " or " + other.expr, or: true}
+}
+
+// String returns the filter expression, or "" for an empty filter.
+func (f ScimFilter) String() string {
+	return f.expr
+}
+
+// grouped returns the expression, parenthesized if it is an "or", for use
+// as an operand of "and", which binds more tightly.
+func (f ScimFilter) grouped() string {
+	if f.or {
+		return "(" + f.expr + ")"
+	}
+	return f.expr
+}
+
+func scimCompare(attr, op, value string) ScimFilter {
+	return ScimFilter{expr: attr + " " + op + " " + scimQuote(value)}
+}
+
+// scimQuote renders value as a JSON string, as RFC 7644 requires for filter
+// values, escaping double quotes, backslashes, and control characters.
+func scimQuote(value string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail.
+	_ = enc.Encode(value)
+	return string(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+}
@@ -1,18 +1,23 @@
 package coreauth
 
+import (
+	"fmt"
+	"time"
+)
+
 // ScimUser represents a SCIM 2.0 user resource.
 type ScimUser struct {
-	Schemas      []string           `json:"schemas,omitempty"`
-	ID           string             `json:"id"`
-	ExternalID   *string            `json:"externalId,omitempty"`
-	UserName     string             `json:"userName"`
-	Name         map[string]any     `json:"name,omitempty"`
-	DisplayName  *string            `json:"displayName,omitempty"`
-	Emails       []map[string]any   `json:"emails,omitempty"`
-	PhoneNumbers []map[string]any   `json:"phoneNumbers,omitempty"`
-	Active       *bool              `json:"active,omitempty"`
-	Groups       []map[string]any   `json:"groups,omitempty"`
-	Meta         map[string]any     `json:"meta,omitempty"`
+	Schemas      []string         `json:"schemas,omitempty"`
+	ID           string           `json:"id"`
+	ExternalID   *string          `json:"externalId,omitempty"`
+	UserName     string           `json:"userName"`
+	Name         map[string]any   `json:"name,omitempty"`
+	DisplayName  *string          `json:"displayName,omitempty"`
+	Emails       []map[string]any `json:"emails,omitempty"`
+	PhoneNumbers []map[string]any `json:"phoneNumbers,omitempty"`
+	Active       *bool            `json:"active,omitempty"`
+	Groups       []map[string]any `json:"groups,omitempty"`
+	Meta         map[string]any   `json:"meta,omitempty"`
 }
 
 // CreateScimUserRequest represents a request to create a SCIM user.
@@ -38,6 +43,18 @@ type ScimGroup struct {
 	Meta        map[string]any   `json:"meta,omitempty"`
 }
 
+// MemberIDs returns the IDs (the "value" attribute) of the group's members,
+// in order, skipping members without one.
+func (g *ScimGroup) MemberIDs() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, m := range g.Members {
+		if id, ok := m["value"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // CreateScimGroupRequest represents a request to create a SCIM group.
 type CreateScimGroupRequest struct {
 	Schemas     []string         `json:"schemas,omitempty"`
@@ -77,12 +94,33 @@ type ScimTokenResponse struct {
 	CreatedAt   *string `json:"created_at,omitempty"`
 }
 
+// ExpiresAtTime returns the parsed expiry time, or the zero time if the token does not expire.
+func (t ScimTokenResponse) ExpiresAtTime() time.Time {
+	return parseTimestamp(t.ExpiresAt)
+}
+
+// CreatedAtTime returns the parsed creation time, or the zero time if unknown.
+func (t ScimTokenResponse) CreatedAtTime() time.Time {
+	return parseTimestamp(t.CreatedAt)
+}
+
 // ScimTokenWithSecret represents a SCIM token with its secret exposed (returned only on creation).
+// The secret is redacted when the value is formatted, so it is not leaked into logs.
 type ScimTokenWithSecret struct {
 	ScimTokenResponse
 	Secret string `json:"secret"`
 }
 
+// String implements fmt.Stringer with the secret redacted.
+func (t ScimTokenWithSecret) String() string {
+	return fmt.Sprintf("ScimTokenWithSecret{ID:%s Name:%s TokenPrefix:%s Secret:%s}", t.ID, t.Name, t.TokenPrefix, redactSecret(t.Secret))
+}
+
+// GoString implements fmt.GoStringer with the secret redacted.
+func (t ScimTokenWithSecret) GoString() string {
+	return t.String()
+}
+
 // CreateScimTokenRequest represents a request to create a SCIM provisioning token.
 type CreateScimTokenRequest struct {
 	Name      string  `json:"name"`
@@ -99,6 +137,9 @@ type SessionInfo struct {
 	LastActiveAt    *string `json:"last_active_at,omitempty"`
 	ExpiresAt       *string `json:"expires_at,omitempty"`
 	CreatedAt       *string `json:"created_at,omitempty"`
+	// IsCurrent reports whether the server flagged this as the session
+	// making the request.
+	IsCurrent bool `json:"is_current,omitempty"`
 }
 
 // OidcProvider represents an OIDC identity provider configuration.
@@ -148,8 +189,27 @@ type UpdateOidcProviderRequest struct {
 	ClientSecret *string `json:"client_secret,omitempty"`
 }
 
+// OidcProviderToggleRequest is sent by ScimService.UpdateOidcProviderTyped.
+type OidcProviderToggleRequest struct {
+	IsEnabled bool `json:"is_enabled"`
+}
+
 // SsoCheckResponse represents the result of an SSO availability check.
 type SsoCheckResponse struct {
-	HasSSO    bool               `json:"has_sso"`
-	Providers []map[string]any   `json:"providers"`
+	HasSSO    bool             `json:"has_sso"`
+	Providers []map[string]any `json:"providers"`
+}
+
+// SsoProvider is an SSO provider listed by ScimService.SSOCheck.
+type SsoProvider struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ProviderType string `json:"provider_type"`
+	TenantID     string `json:"tenant_id"`
+}
+
+// OidcLoginResponse is the result of ScimService.OidcLogin.
+type OidcLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
 }
@@ -0,0 +1,524 @@
+// Code generated by genapi; DO NOT EDIT.
+
+package coreauth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+)
+
+// AdminAPI is the method set of *AdminService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type AdminAPI interface {
+	ListTenants(ctx context.Context) (json.RawMessage, error)
+	ListTenantsInto(ctx context.Context, out any) error
+	ListTenantsTyped(ctx context.Context) ([]TenantRegistryResponse, error)
+	CreateTenant(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateTenantInto(ctx context.Context, data map[string]any, out any) error
+	GetStats(ctx context.Context) (json.RawMessage, error)
+	GetStatsInto(ctx context.Context, out any) error
+	GetTenant(ctx context.Context, tenantID string) (json.RawMessage, error)
+	GetTenantInto(ctx context.Context, tenantID string, out any) error
+	GetTenantTyped(ctx context.Context, tenantID string) (*TenantRegistryResponse, error)
+	ConfigureDatabase(ctx context.Context, tenantID string, data map[string]any) (json.RawMessage, error)
+	ConfigureDatabaseInto(ctx context.Context, tenantID string, data map[string]any, out any) error
+	Activate(ctx context.Context, tenantID string) (json.RawMessage, error)
+	ActivateInto(ctx context.Context, tenantID string, out any) error
+	Suspend(ctx context.Context, tenantID string) (json.RawMessage, error)
+	SuspendInto(ctx context.Context, tenantID string, out any) error
+	TestConnection(ctx context.Context, tenantID string) (json.RawMessage, error)
+	TestConnectionInto(ctx context.Context, tenantID string, out any) error
+	CreateAction(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	CreateActionInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	ListActions(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListActionsInto(ctx context.Context, orgID string, out any) error
+	GetAction(ctx context.Context, orgID, actionID string) (json.RawMessage, error)
+	GetActionInto(ctx context.Context, orgID, actionID string, out any) error
+	GetActionTyped(ctx context.Context, orgID, actionID string) (*Action, error)
+	UpdateAction(ctx context.Context, orgID, actionID string, data map[string]any) (json.RawMessage, error)
+	UpdateActionInto(ctx context.Context, orgID, actionID string, data map[string]any, out any) error
+	DeleteAction(ctx context.Context, orgID, actionID string) error
+	TestAction(ctx context.Context, orgID, actionID string, data map[string]any) (json.RawMessage, error)
+	TestActionInto(ctx context.Context, orgID, actionID string, data map[string]any, out any) error
+	GetActionExecutions(ctx context.Context, orgID, actionID string) (json.RawMessage, error)
+	GetActionExecutionsInto(ctx context.Context, orgID, actionID string, out any) error
+	GetActionExecutionsTyped(ctx context.Context, orgID, actionID string) ([]ActionExecution, error)
+	GetOrgExecutions(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetOrgExecutionsInto(ctx context.Context, orgID string, out any) error
+	StreamExecutions(ctx context.Context, orgID string) (<-chan ActionExecution, <-chan error)
+	GetRateLimits(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetRateLimitsInto(ctx context.Context, orgID string, out any) error
+	UpdateRateLimits(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	UpdateRateLimitsInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	GetTokenClaims(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetTokenClaimsInto(ctx context.Context, orgID string, out any) error
+	UpdateTokenClaims(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	UpdateTokenClaimsInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	Health(ctx context.Context) (json.RawMessage, error)
+	HealthInto(ctx context.Context, out any) error
+}
+
+// ApplicationsAPI is the method set of *ApplicationsService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type ApplicationsAPI interface {
+	Create(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateInto(ctx context.Context, data map[string]any, out any) error
+	List(ctx context.Context) (json.RawMessage, error)
+	ListInto(ctx context.Context, out any) error
+	Get(ctx context.Context, appID string) (json.RawMessage, error)
+	GetInto(ctx context.Context, appID string, out any) error
+	Update(ctx context.Context, appID string, data map[string]any) (json.RawMessage, error)
+	UpdateInto(ctx context.Context, appID string, data map[string]any, out any) error
+	RotateSecret(ctx context.Context, appID string) (json.RawMessage, error)
+	RotateSecretInto(ctx context.Context, appID string, out any) error
+	Delete(ctx context.Context, appID string) error
+	Authenticate(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	AuthenticateInto(ctx context.Context, data map[string]any, out any) error
+	AuthenticateTyped(ctx context.Context, req AuthenticateAppRequest) (*TokenResponse, error)
+	CreateOAuthApp(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateOAuthAppInto(ctx context.Context, data map[string]any, out any) error
+	CreateOAuthAppTyped(ctx context.Context, req CreateOAuthAppRequest) (*ApplicationWithSecret, error)
+	ListOAuthApps(ctx context.Context) (json.RawMessage, error)
+	ListOAuthAppsInto(ctx context.Context, out any) error
+	GetOAuthApp(ctx context.Context, appID string) (json.RawMessage, error)
+	GetOAuthAppInto(ctx context.Context, appID string, out any) error
+	UpdateOAuthApp(ctx context.Context, appID string, data map[string]any) (json.RawMessage, error)
+	UpdateOAuthAppInto(ctx context.Context, appID string, data map[string]any, out any) error
+	RotateOAuthSecret(ctx context.Context, appID string) (json.RawMessage, error)
+	RotateOAuthSecretInto(ctx context.Context, appID string, out any) error
+	DeleteOAuthApp(ctx context.Context, appID string) error
+	ListEmailTemplates(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListEmailTemplatesInto(ctx context.Context, orgID string, out any) error
+	ListEmailTemplatesTyped(ctx context.Context, orgID string) ([]EmailTemplate, error)
+	ResetAllEmailTemplates(ctx context.Context, orgID string) (int, error)
+	GetEmailTemplate(ctx context.Context, orgID, templateID string) (json.RawMessage, error)
+	GetEmailTemplateInto(ctx context.Context, orgID, templateID string, out any) error
+	UpdateEmailTemplate(ctx context.Context, orgID, templateID string, data map[string]any) (json.RawMessage, error)
+	UpdateEmailTemplateInto(ctx context.Context, orgID, templateID string, data map[string]any, out any) error
+	DeleteEmailTemplate(ctx context.Context, orgID, templateID string) error
+	PreviewEmailTemplate(ctx context.Context, orgID, templateID string, data map[string]any) (json.RawMessage, error)
+	PreviewEmailTemplateInto(ctx context.Context, orgID, templateID string, data map[string]any, out any) error
+}
+
+// AuditAPI is the method set of *AuditService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type AuditAPI interface {
+	Query(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	QueryInto(ctx context.Context, params map[string]string, out any) error
+	QueryTyped(ctx context.Context, q AuditQuery) (*AuditLogsResponse, error)
+	QueryPaginator(params map[string]string, pageSize int) *Paginator[AuditLog]
+	ListAll(ctx context.Context, params map[string]string) ([]AuditLog, error)
+	Get(ctx context.Context, logID string) (json.RawMessage, error)
+	GetInto(ctx context.Context, logID string, out any) error
+	SecurityEvents(ctx context.Context) (json.RawMessage, error)
+	SecurityEventsInto(ctx context.Context, out any) error
+	SecurityEventsTyped(ctx context.Context, filter SecurityEventFilter) ([]SecurityEvent, error)
+	FailedLogins(ctx context.Context, userID string) (json.RawMessage, error)
+	FailedLoginsInto(ctx context.Context, userID string, out any) error
+	FailedLoginsSince(ctx context.Context, userID string, since time.Time) (json.RawMessage, error)
+	Export(ctx context.Context) (json.RawMessage, error)
+	ExportInto(ctx context.Context, out any) error
+	ExportRange(ctx context.Context, from, to time.Time) (json.RawMessage, error)
+	ExportRangeInto(ctx context.Context, from, to time.Time, out any) error
+	ExportTo(ctx context.Context, from, to time.Time, w io.Writer) error
+	Stats(ctx context.Context) (json.RawMessage, error)
+	StatsInto(ctx context.Context, out any) error
+	EventTypes(ctx context.Context) ([]string, error)
+	EventCategories(ctx context.Context) ([]string, error)
+	LoginHistory(ctx context.Context) (json.RawMessage, error)
+	LoginHistoryInto(ctx context.Context, out any) error
+	LoginHistoryTyped(ctx context.Context, filter LoginHistoryFilter) ([]LoginEvent, error)
+	SecurityAuditLogs(ctx context.Context) (json.RawMessage, error)
+	SecurityAuditLogsInto(ctx context.Context, out any) error
+}
+
+// AuthAPI is the method set of *AuthService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type AuthAPI interface {
+	Register(ctx context.Context, req RegisterRequest) (json.RawMessage, error)
+	RegisterInto(ctx context.Context, req RegisterRequest, out any) error
+	Login(ctx context.Context, req LoginRequest) (json.RawMessage, error)
+	LoginInto(ctx context.Context, req LoginRequest, out any) error
+	LoginTyped(ctx context.Context, req LoginRequest) (*AuthResponse, error)
+	LoginHierarchical(ctx context.Context, req HierarchicalLoginRequest) (json.RawMessage, error)
+	LoginHierarchicalInto(ctx context.Context, req HierarchicalLoginRequest, out any) error
+	RefreshToken(ctx context.Context, refreshToken string) (json.RawMessage, error)
+	RefreshTokenInto(ctx context.Context, refreshToken string, out any) error
+	Logout(ctx context.Context) error
+	GetProfile(ctx context.Context) (json.RawMessage, error)
+	GetProfileInto(ctx context.Context, out any) error
+	UpdateProfile(ctx context.Context, req UpdateProfileRequest) (json.RawMessage, error)
+	UpdateProfileInto(ctx context.Context, req UpdateProfileRequest, out any) error
+	ChangePassword(ctx context.Context, req ChangePasswordRequest) (json.RawMessage, error)
+	ChangePasswordInto(ctx context.Context, req ChangePasswordRequest, out any) error
+	ChangePasswordChecked(ctx context.Context, req ChangePasswordRequest, policy *SecuritySettings) error
+	VerifyEmail(ctx context.Context, token string) (json.RawMessage, error)
+	VerifyEmailInto(ctx context.Context, token string, out any) error
+	VerifyEmailTyped(ctx context.Context, token string) (*VerifyEmailResult, error)
+	ResendVerification(ctx context.Context) (json.RawMessage, error)
+	ResendVerificationInto(ctx context.Context, out any) error
+	ResendVerificationTyped(ctx context.Context) (*ResendResult, error)
+	ForgotPassword(ctx context.Context, tenantID, email string) (json.RawMessage, error)
+	ForgotPasswordInto(ctx context.Context, tenantID, email string, out any) error
+	VerifyResetToken(ctx context.Context, token string) (json.RawMessage, error)
+	VerifyResetTokenInto(ctx context.Context, token string, out any) error
+	ResetPassword(ctx context.Context, token, newPassword string) (json.RawMessage, error)
+	ResetPasswordInto(ctx context.Context, token, newPassword string, out any) error
+	PasswordlessStart(ctx context.Context, tenantID string, req PasswordlessStartRequest) (json.RawMessage, error)
+	PasswordlessStartInto(ctx context.Context, tenantID string, req PasswordlessStartRequest, out any) error
+	PasswordlessVerify(ctx context.Context, tenantID string, req PasswordlessVerifyRequest) (json.RawMessage, error)
+	PasswordlessVerifyInto(ctx context.Context, tenantID string, req PasswordlessVerifyRequest, out any) error
+	PasswordlessVerifyTyped(ctx context.Context, tenantID string, req PasswordlessVerifyRequest) (*AuthResponse, error)
+	PasswordlessResend(ctx context.Context, tenantID string, data map[string]any) (json.RawMessage, error)
+	PasswordlessResendInto(ctx context.Context, tenantID string, data map[string]any, out any) error
+	CreateLoginFlowBrowser(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	CreateLoginFlowBrowserInto(ctx context.Context, params map[string]string, out any) error
+	CreateLoginFlowAPI(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	CreateLoginFlowAPIInto(ctx context.Context, params map[string]string, out any) error
+	GetLoginFlow(ctx context.Context, flowID string) (json.RawMessage, error)
+	GetLoginFlowInto(ctx context.Context, flowID string, out any) error
+	SubmitLoginFlow(ctx context.Context, flowID string, data map[string]any) (json.RawMessage, error)
+	SubmitLoginFlowInto(ctx context.Context, flowID string, data map[string]any, out any) error
+	CreateRegistrationFlowBrowser(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	CreateRegistrationFlowBrowserInto(ctx context.Context, params map[string]string, out any) error
+	CreateRegistrationFlowAPI(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	CreateRegistrationFlowAPIInto(ctx context.Context, params map[string]string, out any) error
+	GetRegistrationFlow(ctx context.Context, flowID string) (json.RawMessage, error)
+	GetRegistrationFlowInto(ctx context.Context, flowID string, out any) error
+	SubmitRegistrationFlow(ctx context.Context, flowID string, data map[string]any) (json.RawMessage, error)
+	SubmitRegistrationFlowInto(ctx context.Context, flowID string, data map[string]any, out any) error
+	Whoami(ctx context.Context) (json.RawMessage, error)
+	Me(ctx context.Context) (*MeResult, error)
+	WhoamiInto(ctx context.Context, out any) error
+}
+
+// ConnectionsAPI is the method set of *ConnectionsService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type ConnectionsAPI interface {
+	List(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListInto(ctx context.Context, orgID string, out any) error
+	Create(ctx context.Context, orgID string, req CreateConnectionRequest) (json.RawMessage, error)
+	CreateInto(ctx context.Context, orgID string, req CreateConnectionRequest, out any) error
+	CreateTyped(ctx context.Context, orgID string, req CreateConnectionRequest) (*Connection, error)
+	Get(ctx context.Context, orgID, connectionID string) (json.RawMessage, error)
+	GetInto(ctx context.Context, orgID, connectionID string, out any) error
+	Update(ctx context.Context, orgID, connectionID string, req UpdateConnectionRequest) (json.RawMessage, error)
+	UpdateInto(ctx context.Context, orgID, connectionID string, req UpdateConnectionRequest, out any) error
+	Delete(ctx context.Context, orgID, connectionID string) error
+	GetAuthMethods(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetAuthMethodsInto(ctx context.Context, orgID string, out any) error
+	ListAll(ctx context.Context) (json.RawMessage, error)
+	ListAllInto(ctx context.Context, out any) error
+	CreatePlatform(ctx context.Context, req CreateConnectionRequest) (json.RawMessage, error)
+	CreatePlatformInto(ctx context.Context, req CreateConnectionRequest, out any) error
+	CreatePlatformTyped(ctx context.Context, req CreateConnectionRequest) (*Connection, error)
+}
+
+// FgaAPI is the method set of *FgaService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type FgaAPI interface {
+	CreateTuple(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateTupleInto(ctx context.Context, data map[string]any, out any) error
+	DeleteTuple(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	DeleteTupleInto(ctx context.Context, data map[string]any, out any) error
+	QueryTuples(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	QueryTuplesInto(ctx context.Context, params map[string]string, out any) error
+	GetObjectTuples(ctx context.Context, objectType, objectID string) (json.RawMessage, error)
+	GetObjectTuplesInto(ctx context.Context, objectType, objectID string, out any) error
+	GetObjectTuplesTyped(ctx context.Context, objectType, objectID string) ([]RelationTuple, error)
+	GetSubjectTuples(ctx context.Context, subjectType, subjectID string) (json.RawMessage, error)
+	GetSubjectTuplesInto(ctx context.Context, subjectType, subjectID string, out any) error
+	GetSubjectTuplesTyped(ctx context.Context, subjectType, subjectID, relation string) ([]RelationTuple, error)
+	Check(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CheckInto(ctx context.Context, data map[string]any, out any) error
+	CheckTyped(ctx context.Context, req CheckRequest, conditions *CheckContext) (*CheckResponse, error)
+	Expand(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	ExpandInto(ctx context.Context, data map[string]any, out any) error
+	ForwardAuth(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	ForwardAuthInto(ctx context.Context, data map[string]any, out any) error
+	CreateStore(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateStoreInto(ctx context.Context, data map[string]any, out any) error
+	CreateStoreTyped(ctx context.Context, req CreateStoreRequest) (*FgaStore, error)
+	ListStores(ctx context.Context) (json.RawMessage, error)
+	ListStoresInto(ctx context.Context, out any) error
+	GetStore(ctx context.Context, storeID string) (json.RawMessage, error)
+	GetStoreInto(ctx context.Context, storeID string, out any) error
+	GetStoreTyped(ctx context.Context, storeID string) (*FgaStore, error)
+	UpdateStore(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error)
+	UpdateStoreInto(ctx context.Context, storeID string, data map[string]any, out any) error
+	UpdateStoreTyped(ctx context.Context, storeID string, req UpdateStoreRequest) (*FgaStore, error)
+	DeleteStore(ctx context.Context, storeID string) error
+	WriteModel(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error)
+	WriteModelInto(ctx context.Context, storeID string, data map[string]any, out any) error
+	ListModels(ctx context.Context, storeID string) (json.RawMessage, error)
+	ListModelsInto(ctx context.Context, storeID string, out any) error
+	GetCurrentModel(ctx context.Context, storeID string) (json.RawMessage, error)
+	GetCurrentModelInto(ctx context.Context, storeID string, out any) error
+	GetModelVersion(ctx context.Context, storeID, modelID string) (json.RawMessage, error)
+	GetModelVersionInto(ctx context.Context, storeID, modelID string, out any) error
+	GetCurrentModelTyped(ctx context.Context, storeID string) (*AuthorizationModel, error)
+	GetModelVersionTyped(ctx context.Context, storeID, modelID string) (*AuthorizationModel, error)
+	IsCurrentModel(ctx context.Context, storeID, modelID string) (bool, error)
+	CreateAPIKey(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error)
+	CreateAPIKeyInto(ctx context.Context, storeID string, data map[string]any, out any) error
+	ListAPIKeys(ctx context.Context, storeID string) (json.RawMessage, error)
+	ListAPIKeysInto(ctx context.Context, storeID string, out any) error
+	ListAPIKeysTyped(ctx context.Context, storeID string) ([]FgaStoreApiKey, error)
+	RevokeAPIKey(ctx context.Context, storeID, keyID string) error
+	StoreCheck(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error)
+	StoreCheckInto(ctx context.Context, storeID string, data map[string]any, out any) error
+	BatchCheck(ctx context.Context, storeID string, reqs []CheckRequest) ([]CheckResponse, error)
+	ListObjects(ctx context.Context, storeID string, req ListObjectsRequest) ([]string, error)
+	ListSubjects(ctx context.Context, storeID string, req ListSubjectsRequest) ([]string, error)
+	ReadStoreTuples(ctx context.Context, storeID string, params map[string]string) (json.RawMessage, error)
+	ReadStoreTuplesInto(ctx context.Context, storeID string, params map[string]string, out any) error
+	WriteStoreTuples(ctx context.Context, storeID string, data map[string]any) (json.RawMessage, error)
+	WriteStoreTuplesInto(ctx context.Context, storeID string, data map[string]any, out any) error
+	Bootstrap(ctx context.Context, req BootstrapRequest) (*BootstrapResult, error)
+	ImportTuples(ctx context.Context, storeID string, r io.Reader, format string) (*BatchResult, error)
+	ImportTuplesWithProgress(ctx context.Context, storeID string, r io.Reader, format string, progress BatchProgressFunc) (*BatchResult, error)
+	WriteTuplesBatched(ctx context.Context, storeID string, writes, deletes []map[string]any, chunkSize int) (*BatchResult, error)
+	ExportTuples(ctx context.Context, storeID string, w io.Writer, format string) error
+	SyncTuples(ctx context.Context, storeID string, desired []CreateTupleRequest) (*ReconcileResult, error)
+	StoreTuplesPaginator(storeID string, params map[string]string, pageSize int) *Paginator[RelationTuple]
+	ListAllStoreTuples(ctx context.Context, storeID string, params map[string]string) ([]RelationTuple, error)
+	QueryTuplesPage(ctx context.Context, filter QueryTuplesRequest, pageSize int, continuation string) ([]RelationTuple, string, error)
+}
+
+// GroupsAPI is the method set of *GroupsService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type GroupsAPI interface {
+	Create(ctx context.Context, tenantID string, data map[string]any) (json.RawMessage, error)
+	CreateInto(ctx context.Context, tenantID string, data map[string]any, out any) error
+	List(ctx context.Context, tenantID string) (json.RawMessage, error)
+	ListInto(ctx context.Context, tenantID string, out any) error
+	Get(ctx context.Context, tenantID, groupID string) (json.RawMessage, error)
+	GetInto(ctx context.Context, tenantID, groupID string, out any) error
+	Update(ctx context.Context, tenantID, groupID string, data map[string]any) (json.RawMessage, error)
+	UpdateInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error
+	Delete(ctx context.Context, tenantID, groupID string) error
+	AddMember(ctx context.Context, tenantID, groupID string, data map[string]any) (json.RawMessage, error)
+	AddMemberInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error
+	ListMembers(ctx context.Context, tenantID, groupID string) (json.RawMessage, error)
+	ListMembersInto(ctx context.Context, tenantID, groupID string, out any) error
+	UpdateMember(ctx context.Context, tenantID, groupID, userID string, data map[string]any) (json.RawMessage, error)
+	UpdateMemberInto(ctx context.Context, tenantID, groupID, userID string, data map[string]any, out any) error
+	RemoveMember(ctx context.Context, tenantID, groupID, userID string) error
+	AssignRole(ctx context.Context, tenantID, groupID string, data map[string]any) (json.RawMessage, error)
+	AssignRoleInto(ctx context.Context, tenantID, groupID string, data map[string]any, out any) error
+	ListRoles(ctx context.Context, tenantID, groupID string) (json.RawMessage, error)
+	ListRolesInto(ctx context.Context, tenantID, groupID string, out any) error
+	RemoveRole(ctx context.Context, tenantID, groupID, roleID string) error
+	GetUserGroups(ctx context.Context, tenantID, userID string) (json.RawMessage, error)
+	GetUserGroupsInto(ctx context.Context, tenantID, userID string, out any) error
+	SyncMembers(ctx context.Context, tenantID, groupID string, userIDs []string) (*ReconcileResult, error)
+	SyncRoles(ctx context.Context, tenantID, groupID string, roleIDs []string) (*ReconcileResult, error)
+	CreateInvitation(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	CreateInvitationInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	ListInvitations(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListInvitationsInto(ctx context.Context, orgID string, out any) error
+	InvitationsPaginator(orgID string, pageSize int) *Paginator[InvitationResponse]
+	ListAllInvitations(ctx context.Context, orgID string) ([]InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, orgID, invitationID string) error
+	ResendInvitation(ctx context.Context, orgID, invitationID string) (json.RawMessage, error)
+	ResendInvitationInto(ctx context.Context, orgID, invitationID string, out any) error
+	VerifyInvitation(ctx context.Context, token string) (json.RawMessage, error)
+	VerifyInvitationInto(ctx context.Context, token string, out any) error
+	AcceptInvitation(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	AcceptInvitationInto(ctx context.Context, data map[string]any, out any) error
+}
+
+// MfaAPI is the method set of *MfaService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type MfaAPI interface {
+	EnrollTOTP(ctx context.Context) (json.RawMessage, error)
+	EnrollTOTPInto(ctx context.Context, out any) error
+	VerifyTOTP(ctx context.Context, methodID, code string) (json.RawMessage, error)
+	VerifyTOTPInto(ctx context.Context, methodID, code string, out any) error
+	EnrollAndVerifyTOTP(ctx context.Context, totpCode func(secret string) (string, error)) (*MfaMethod, error)
+	EnrollSMS(ctx context.Context, phoneNumber string) (json.RawMessage, error)
+	EnrollSMSInto(ctx context.Context, phoneNumber string, out any) error
+	VerifySMS(ctx context.Context, methodID, code string) (json.RawMessage, error)
+	VerifySMSInto(ctx context.Context, methodID, code string, out any) error
+	ResendSMS(ctx context.Context, methodID string) (json.RawMessage, error)
+	ResendSMSInto(ctx context.Context, methodID string, out any) error
+	ListMethods(ctx context.Context) (json.RawMessage, error)
+	ListMethodsInto(ctx context.Context, out any) error
+	ListMethodsTyped(ctx context.Context) ([]MfaMethod, error)
+	DeleteMethod(ctx context.Context, methodID string) error
+	RegenerateBackupCodes(ctx context.Context) (json.RawMessage, error)
+	RegenerateBackupCodesInto(ctx context.Context, out any) error
+	EnrollTOTPWithToken(ctx context.Context, enrollmentToken string) (json.RawMessage, error)
+	EnrollTOTPWithTokenInto(ctx context.Context, enrollmentToken string, out any) error
+	VerifyTOTPWithToken(ctx context.Context, methodID, enrollmentToken, code string) (json.RawMessage, error)
+	VerifyTOTPWithTokenInto(ctx context.Context, methodID, enrollmentToken, code string, out any) error
+}
+
+// OAuth2API is the method set of *OAuth2Service. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type OAuth2API interface {
+	VerifyIDToken(ctx context.Context, idToken string, expectedAudience string) (*IDTokenClaims, error)
+	VerifyJWT(ctx context.Context, token string, opts *VerifyOptions) (*VerifiedToken, error)
+	Discovery(ctx context.Context) (json.RawMessage, error)
+	DiscoveryInto(ctx context.Context, out any) error
+	JWKS(ctx context.Context) (json.RawMessage, error)
+	JWKSInto(ctx context.Context, out any) error
+	AuthorizeURL(clientID, redirectURI string, params map[string]string) string
+	AuthorizeURLOpts(clientID, redirectURI string, opts AuthorizeOptions) string
+	Token(ctx context.Context, data url.Values) (json.RawMessage, error)
+	TokenInto(ctx context.Context, data url.Values, out any) error
+	ExchangeCode(ctx context.Context, req ExchangeCodeRequest) (*TokenResponse, error)
+	ClientCredentials(ctx context.Context, clientID, clientSecret string, scopes []string, basicAuth bool) (*TokenResponse, error)
+	Userinfo(ctx context.Context) (json.RawMessage, error)
+	UserinfoInto(ctx context.Context, out any) error
+	Revoke(ctx context.Context, token string, tokenTypeHint *string) (json.RawMessage, error)
+	RevokeInto(ctx context.Context, token string, tokenTypeHint *string, out any) error
+	Introspect(ctx context.Context, token string, tokenTypeHint *string) (json.RawMessage, error)
+	IntrospectInto(ctx context.Context, token string, tokenTypeHint *string, out any) error
+	IntrospectTyped(ctx context.Context, token string, tokenTypeHint *string) (*IntrospectionResponse, error)
+	OidcLogout(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	OidcLogoutInto(ctx context.Context, params map[string]string, out any) error
+}
+
+// ScimAPI is the method set of *ScimService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type ScimAPI interface {
+	GetConfig(ctx context.Context) (json.RawMessage, error)
+	GetConfigInto(ctx context.Context, out any) error
+	GetResourceTypes(ctx context.Context) (json.RawMessage, error)
+	GetResourceTypesInto(ctx context.Context, out any) error
+	GetSchemas(ctx context.Context) (json.RawMessage, error)
+	GetSchemasInto(ctx context.Context, out any) error
+	RawRequest(ctx context.Context, method, path string, body json.RawMessage) (json.RawMessage, error)
+	RawRequestInto(ctx context.Context, method, path string, body json.RawMessage, out any) error
+	ListUsers(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	ListUsersInto(ctx context.Context, params map[string]string, out any) error
+	UsersPaginator(filter string, count int) *Paginator[ScimUser]
+	ListAllUsers(ctx context.Context, filter string) ([]ScimUser, error)
+	CreateUser(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateUserInto(ctx context.Context, data map[string]any, out any) error
+	GetUser(ctx context.Context, userID string) (json.RawMessage, error)
+	GetUserInto(ctx context.Context, userID string, out any) error
+	ReplaceUser(ctx context.Context, userID string, data map[string]any) (json.RawMessage, error)
+	ReplaceUserInto(ctx context.Context, userID string, data map[string]any, out any) error
+	PatchUser(ctx context.Context, userID string, data map[string]any) (json.RawMessage, error)
+	PatchUserInto(ctx context.Context, userID string, data map[string]any, out any) error
+	ReplaceUserTyped(ctx context.Context, userID string, data map[string]any) (*ScimUser, error)
+	PatchUserTyped(ctx context.Context, userID string, data map[string]any) (*ScimUser, error)
+	DeleteUser(ctx context.Context, userID string) error
+	ListScimGroups(ctx context.Context, params map[string]string) (json.RawMessage, error)
+	ListScimGroupsInto(ctx context.Context, params map[string]string, out any) error
+	GroupsPaginator(filter string, count int) *Paginator[ScimGroup]
+	ListAllGroups(ctx context.Context, filter string) ([]ScimGroup, error)
+	CreateScimGroup(ctx context.Context, data map[string]any) (json.RawMessage, error)
+	CreateScimGroupInto(ctx context.Context, data map[string]any, out any) error
+	GetScimGroup(ctx context.Context, groupID string) (json.RawMessage, error)
+	GetScimGroupInto(ctx context.Context, groupID string, out any) error
+	GetScimGroupTyped(ctx context.Context, groupID string) (*ScimGroup, error)
+	PatchScimGroup(ctx context.Context, groupID string, data map[string]any) (json.RawMessage, error)
+	PatchScimGroupInto(ctx context.Context, groupID string, data map[string]any, out any) error
+	DeleteScimGroup(ctx context.Context, groupID string) error
+	ListScimTokens(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListScimTokensInto(ctx context.Context, orgID string, out any) error
+	CreateScimToken(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	CreateScimTokenInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	ListScimTokensTyped(ctx context.Context, orgID string) ([]ScimTokenResponse, error)
+	CreateScimTokenTyped(ctx context.Context, orgID string, req CreateScimTokenRequest) (*ScimTokenWithSecret, error)
+	RevokeScimToken(ctx context.Context, orgID, tokenID string) error
+	RevokeTokensOlderThan(ctx context.Context, orgID string, cutoff time.Time) (int, error)
+	ListSessions(ctx context.Context) (json.RawMessage, error)
+	ListSessionsInto(ctx context.Context, out any) error
+	ListSessionsTyped(ctx context.Context) ([]SessionInfo, error)
+	RevokeOtherSessions(ctx context.Context, currentSessionID string) (int, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeSessionChecked(ctx context.Context, sessionID string) error
+	RevokeAllSessions(ctx context.Context) error
+	ListOidcProviders(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListOidcProvidersInto(ctx context.Context, orgID string, out any) error
+	CreateOidcProvider(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	CreateOidcProviderInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	UpdateOidcProvider(ctx context.Context, orgID, providerID string, data map[string]any) (json.RawMessage, error)
+	UpdateOidcProviderInto(ctx context.Context, orgID, providerID string, data map[string]any, out any) error
+	UpdateOidcProviderTyped(ctx context.Context, providerID string, req OidcProviderToggleRequest) (*OidcProvider, error)
+	DeleteOidcProvider(ctx context.Context, orgID, providerID string) error
+	ListPublicProviders(ctx context.Context, orgSlug string) (json.RawMessage, error)
+	ListPublicProvidersInto(ctx context.Context, orgSlug string, out any) error
+	ListProviderTemplates(ctx context.Context) (json.RawMessage, error)
+	ListProviderTemplatesInto(ctx context.Context, out any) error
+	GetProviderTemplate(ctx context.Context, templateName string) (json.RawMessage, error)
+	GetProviderTemplateInto(ctx context.Context, templateName string, out any) error
+	SSOCheck(ctx context.Context, email string) (json.RawMessage, error)
+	SSOCheckInto(ctx context.Context, email string, out any) error
+	OidcLogin(ctx context.Context, tenantID, providerID, redirectURI string) (json.RawMessage, error)
+	OidcLoginInto(ctx context.Context, tenantID, providerID, redirectURI string, out any) error
+}
+
+// TenantsAPI is the method set of *TenantsService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type TenantsAPI interface {
+	Create(ctx context.Context, req CreateTenantRequest) (json.RawMessage, error)
+	CreateInto(ctx context.Context, req CreateTenantRequest, out any) error
+	CreateTyped(ctx context.Context, req CreateTenantRequest) (*CreateTenantResponse, error)
+	GetBySlug(ctx context.Context, slug string) (json.RawMessage, error)
+	GetBySlugInto(ctx context.Context, slug string, out any) error
+	ListUsers(ctx context.Context, tenantID string) (json.RawMessage, error)
+	ListUsersInto(ctx context.Context, tenantID string, out any) error
+	UpdateUserRole(ctx context.Context, tenantID, userID, role string) (json.RawMessage, error)
+	UpdateUserRoleInto(ctx context.Context, tenantID, userID, role string, out any) error
+	GetSecurity(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetSecurityInto(ctx context.Context, orgID string, out any) error
+	GetSecurityTyped(ctx context.Context, orgID string) (*SecuritySettingsView, error)
+	UpdateSecurity(ctx context.Context, orgID string, req SecuritySettings) (json.RawMessage, error)
+	UpdateSecurityInto(ctx context.Context, orgID string, req SecuritySettings, out any) error
+	GetBranding(ctx context.Context, orgID string) (json.RawMessage, error)
+	GetBrandingInto(ctx context.Context, orgID string, out any) error
+	GetBrandingTyped(ctx context.Context, orgID string) (*BrandingSettings, error)
+	UpdateBranding(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	UpdateBrandingInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	UpdateBrandingTyped(ctx context.Context, orgID string, settings BrandingSettings) (*BrandingSettings, error)
+}
+
+// WebhooksAPI is the method set of *WebhooksService. Depend on it instead of the
+// concrete type to substitute a mock in tests.
+type WebhooksAPI interface {
+	Create(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error)
+	CreateInto(ctx context.Context, orgID string, data map[string]any, out any) error
+	List(ctx context.Context, orgID string) (json.RawMessage, error)
+	ListInto(ctx context.Context, orgID string, out any) error
+	Get(ctx context.Context, orgID, webhookID string) (json.RawMessage, error)
+	GetInto(ctx context.Context, orgID, webhookID string, out any) error
+	Update(ctx context.Context, orgID, webhookID string, data map[string]any) (json.RawMessage, error)
+	UpdateInto(ctx context.Context, orgID, webhookID string, data map[string]any, out any) error
+	Delete(ctx context.Context, orgID, webhookID string) error
+	RotateSecret(ctx context.Context, orgID, webhookID string) (json.RawMessage, error)
+	RotateSecretInto(ctx context.Context, orgID, webhookID string, out any) error
+	Test(ctx context.Context, orgID, webhookID string) (json.RawMessage, error)
+	TestInto(ctx context.Context, orgID, webhookID string, out any) error
+	TestAll(ctx context.Context, orgID string) (map[string]*TestWebhookResponse, error)
+	ListDeliveries(ctx context.Context, orgID, webhookID string, params map[string]string) (json.RawMessage, error)
+	ListDeliveriesInto(ctx context.Context, orgID, webhookID string, params map[string]string, out any) error
+	GetDelivery(ctx context.Context, orgID, webhookID, deliveryID string) (json.RawMessage, error)
+	GetDeliveryInto(ctx context.Context, orgID, webhookID, deliveryID string, out any) error
+	RetryDelivery(ctx context.Context, orgID, webhookID, deliveryID string) (json.RawMessage, error)
+	RetryDeliveryInto(ctx context.Context, orgID, webhookID, deliveryID string, out any) error
+	ListEventTypes(ctx context.Context) (json.RawMessage, error)
+	ListEventTypesInto(ctx context.Context, out any) error
+}
+
+var (
+	_ AdminAPI        = (*AdminService)(nil)
+	_ ApplicationsAPI = (*ApplicationsService)(nil)
+	_ AuditAPI        = (*AuditService)(nil)
+	_ AuthAPI         = (*AuthService)(nil)
+	_ ConnectionsAPI  = (*ConnectionsService)(nil)
+	_ FgaAPI          = (*FgaService)(nil)
+	_ GroupsAPI       = (*GroupsService)(nil)
+	_ MfaAPI          = (*MfaService)(nil)
+	_ OAuth2API       = (*OAuth2Service)(nil)
+	_ ScimAPI         = (*ScimService)(nil)
+	_ TenantsAPI      = (*TenantsService)(nil)
+	_ WebhooksAPI     = (*WebhooksService)(nil)
+)
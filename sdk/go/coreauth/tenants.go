@@ -16,37 +16,127 @@ func (s *TenantsService) Create(ctx context.Context, req CreateTenantRequest) (j
 	return s.http.post(ctx, "/api/tenants", req)
 }
 
+// CreateInto is like Create but decodes the response into out.
+func (s *TenantsService) CreateInto(ctx context.Context, req CreateTenantRequest, out any) error {
+	raw, err := s.Create(ctx, req)
+	return decodeResult(raw, err, out)
+}
+
+// CreateTyped creates a new tenant and returns the decoded response. Use
+// NextSteps on the result to find any required follow-ups.
+func (s *TenantsService) CreateTyped(ctx context.Context, req CreateTenantRequest) (*CreateTenantResponse, error) {
+	var resp CreateTenantResponse
+	if err := s.CreateInto(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetBySlug retrieves an organization by its URL slug.
 func (s *TenantsService) GetBySlug(ctx context.Context, slug string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/by-slug/%s", slug), nil)
 }
 
+// GetBySlugInto is like GetBySlug but decodes the response into out.
+func (s *TenantsService) GetBySlugInto(ctx context.Context, slug string, out any) error {
+	raw, err := s.GetBySlug(ctx, slug)
+	return decodeResult(raw, err, out)
+}
+
 // ListUsers returns all users belonging to a tenant.
 func (s *TenantsService) ListUsers(ctx context.Context, tenantID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/tenants/%s/users", tenantID), nil)
 }
 
+// ListUsersInto is like ListUsers but decodes the response into out.
+func (s *TenantsService) ListUsersInto(ctx context.Context, tenantID string, out any) error {
+	raw, err := s.ListUsers(ctx, tenantID)
+	return decodeResult(raw, err, out)
+}
+
 // UpdateUserRole updates a user's role within a tenant.
 func (s *TenantsService) UpdateUserRole(ctx context.Context, tenantID, userID, role string) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/tenants/%s/users/%s/role", tenantID, userID), UpdateUserRoleRequest{Role: role})
 }
 
+// UpdateUserRoleInto is like UpdateUserRole but decodes the response into out.
+func (s *TenantsService) UpdateUserRoleInto(ctx context.Context, tenantID, userID, role string, out any) error {
+	raw, err := s.UpdateUserRole(ctx, tenantID, userID, role)
+	return decodeResult(raw, err, out)
+}
+
 // GetSecurity retrieves the security settings for an organization.
 func (s *TenantsService) GetSecurity(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/security", orgID), nil)
 }
 
+// GetSecurityInto is like GetSecurity but decodes the response into out.
+func (s *TenantsService) GetSecurityInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetSecurity(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
+// GetSecurityTyped retrieves the security settings for an organization.
+// Its SecuritySettings can be modified and passed back to UpdateSecurity, and
+// IsSet reports which settings the organization has set explicitly.
+func (s *TenantsService) GetSecurityTyped(ctx context.Context, orgID string) (*SecuritySettingsView, error) {
+	var settings SecuritySettingsView
+	if err := s.GetSecurityInto(ctx, orgID, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
 // UpdateSecurity updates the security settings for an organization.
 func (s *TenantsService) UpdateSecurity(ctx context.Context, orgID string, req SecuritySettings) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/security", orgID), req)
 }
 
+// UpdateSecurityInto is like UpdateSecurity but decodes the response into out.
+func (s *TenantsService) UpdateSecurityInto(ctx context.Context, orgID string, req SecuritySettings, out any) error {
+	raw, err := s.UpdateSecurity(ctx, orgID, req)
+	return decodeResult(raw, err, out)
+}
+
 // GetBranding retrieves the branding settings for an organization.
 func (s *TenantsService) GetBranding(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/branding", orgID), nil)
 }
 
+// GetBrandingInto is like GetBranding but decodes the response into out.
+func (s *TenantsService) GetBrandingInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.GetBranding(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
+// GetBrandingTyped retrieves the branding settings for an organization.
+// The result can be modified and passed back to UpdateBrandingTyped.
+func (s *TenantsService) GetBrandingTyped(ctx context.Context, orgID string) (*BrandingSettings, error) {
+	var settings BrandingSettings
+	if err := s.GetBrandingInto(ctx, orgID, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
 // UpdateBranding updates the branding settings for an organization.
 func (s *TenantsService) UpdateBranding(ctx context.Context, orgID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/branding", orgID), data)
 }
+
+// UpdateBrandingInto is like UpdateBranding but decodes the response into out.
+func (s *TenantsService) UpdateBrandingInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.UpdateBranding(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
+// UpdateBrandingTyped updates the branding settings for an organization and
+// returns the settings as stored. Nil fields are left unchanged.
+func (s *TenantsService) UpdateBrandingTyped(ctx context.Context, orgID string, settings BrandingSettings) (*BrandingSettings, error) {
+	var updated BrandingSettings
+	raw, err := s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/branding", orgID), settings)
+	if err := decodeResult(raw, err, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -1,5 +1,7 @@
 package coreauth
 
+import "encoding/json"
+
 // CreateTenantRequest represents a request to create a new tenant.
 type CreateTenantRequest struct {
 	Name          string  `json:"name"`
@@ -22,6 +24,25 @@ type CreateTenantResponse struct {
 	DatabaseSetupRequired     *bool   `json:"database_setup_required,omitempty"`
 }
 
+// Follow-up steps returned by CreateTenantResponse.NextSteps.
+const (
+	NextStepVerifyEmail       = "verify_email"
+	NextStepConfigureDatabase = "configure_database"
+)
+
+// NextSteps returns the follow-up steps required before the new tenant is
+// ready, in the order they should be done, or nil if there are none.
+func (r *CreateTenantResponse) NextSteps() []string {
+	var steps []string
+	if r.EmailVerificationRequired != nil && *r.EmailVerificationRequired {
+		steps = append(steps, NextStepVerifyEmail)
+	}
+	if r.DatabaseSetupRequired != nil && *r.DatabaseSetupRequired {
+		steps = append(steps, NextStepConfigureDatabase)
+	}
+	return steps
+}
+
 // SecuritySettings represents tenant security configuration.
 type SecuritySettings struct {
 	MfaRequired              *bool `json:"mfa_required,omitempty"`
@@ -37,6 +58,51 @@ type SecuritySettings struct {
 	EnforceSSO               *bool `json:"enforce_sso,omitempty"`
 }
 
+// SecuritySettingsView is SecuritySettings as read from the server, together
+// with which settings the response actually contained. A nil field in
+// SecuritySettings may mean the setting is unset, so the default or an
+// inherited value applies, or that the server omitted it; IsSet tells the
+// two apart from an explicit value, including an explicit false.
+type SecuritySettingsView struct {
+	SecuritySettings
+	present map[string]bool
+}
+
+// UnmarshalJSON decodes the settings and records which JSON keys were present
+// with a non-null value.
+func (v *SecuritySettingsView) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &v.SecuritySettings); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	v.present = make(map[string]bool, len(fields))
+	for k, raw := range fields {
+		if string(raw) != "null" {
+			v.present[k] = true
+		}
+	}
+	return nil
+}
+
+// IsSet reports whether the response set the setting with the given JSON
+// key, such as "mfa_required", to a value.
+func (v *SecuritySettingsView) IsSet(field string) bool {
+	return v.present[field]
+}
+
+// Present returns the JSON keys the response set to a value, mapped to true.
+// The map is a copy and may be modified.
+func (v *SecuritySettingsView) Present() map[string]bool {
+	present := make(map[string]bool, len(v.present))
+	for k := range v.present {
+		present[k] = true
+	}
+	return present
+}
+
 // BrandingSettings represents tenant branding configuration.
 type BrandingSettings struct {
 	LogoURL         *string `json:"logo_url,omitempty"`
@@ -0,0 +1,7 @@
+package coreauth
+
+// Version is the version of this SDK, sent in the default User-Agent.
+const Version = "0.1.0"
+
+// defaultUserAgent identifies SDK traffic in server logs.
+const defaultUserAgent = "coreauth-go/" + Version
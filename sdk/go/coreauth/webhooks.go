@@ -3,9 +3,14 @@ package coreauth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 )
 
+// testAllConcurrency bounds the number of webhook tests TestAll runs at once.
+const testAllConcurrency = 4
+
 // WebhooksService provides webhook management and delivery operations.
 type WebhooksService struct {
 	http *httpClient
@@ -16,21 +21,45 @@ func (s *WebhooksService) Create(ctx context.Context, orgID string, data map[str
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/webhooks", orgID), data)
 }
 
+// CreateInto is like Create but decodes the response into out.
+func (s *WebhooksService) CreateInto(ctx context.Context, orgID string, data map[string]any, out any) error {
+	raw, err := s.Create(ctx, orgID, data)
+	return decodeResult(raw, err, out)
+}
+
 // List returns all webhooks for an organization.
 func (s *WebhooksService) List(ctx context.Context, orgID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/webhooks", orgID), nil)
 }
 
+// ListInto is like List but decodes the response into out.
+func (s *WebhooksService) ListInto(ctx context.Context, orgID string, out any) error {
+	raw, err := s.List(ctx, orgID)
+	return decodeResult(raw, err, out)
+}
+
 // Get retrieves a specific webhook by ID.
 func (s *WebhooksService) Get(ctx context.Context, orgID, webhookID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s", orgID, webhookID), nil)
 }
 
+// GetInto is like Get but decodes the response into out.
+func (s *WebhooksService) GetInto(ctx context.Context, orgID, webhookID string, out any) error {
+	raw, err := s.Get(ctx, orgID, webhookID)
+	return decodeResult(raw, err, out)
+}
+
 // Update modifies an existing webhook.
 func (s *WebhooksService) Update(ctx context.Context, orgID, webhookID string, data map[string]any) (json.RawMessage, error) {
 	return s.http.put(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s", orgID, webhookID), data)
 }
 
+// UpdateInto is like Update but decodes the response into out.
+func (s *WebhooksService) UpdateInto(ctx context.Context, orgID, webhookID string, data map[string]any, out any) error {
+	raw, err := s.Update(ctx, orgID, webhookID, data)
+	return decodeResult(raw, err, out)
+}
+
 // Delete removes a webhook.
 func (s *WebhooksService) Delete(ctx context.Context, orgID, webhookID string) error {
 	_, err := s.http.del(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s", orgID, webhookID), nil)
@@ -39,12 +68,66 @@ func (s *WebhooksService) Delete(ctx context.Context, orgID, webhookID string) e
 
 // RotateSecret rotates the signing secret for a webhook.
 func (s *WebhooksService) RotateSecret(ctx context.Context, orgID, webhookID string) (json.RawMessage, error) {
-	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/rotate-secret", orgID, webhookID), nil)
+	return s.http.post(withConflictRetry(ctx), fmt.Sprintf("/api/organizations/%s/webhooks/%s/rotate-secret", orgID, webhookID), nil)
 }
 
-// Test sends a test event to a webhook endpoint.
+// RotateSecretInto is like RotateSecret but decodes the response into out.
+func (s *WebhooksService) RotateSecretInto(ctx context.Context, orgID, webhookID string, out any) error {
+	raw, err := s.RotateSecret(ctx, orgID, webhookID)
+	return decodeResult(raw, err, out)
+}
+
+// Test sends a test event to a webhook endpoint. The server requires a JSON
+// body, so an empty object is sent.
 func (s *WebhooksService) Test(ctx context.Context, orgID, webhookID string) (json.RawMessage, error) {
-	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/test", orgID, webhookID), nil)
+	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/test", orgID, webhookID), map[string]any{})
+}
+
+// TestInto is like Test but decodes the response into out.
+func (s *WebhooksService) TestInto(ctx context.Context, orgID, webhookID string, out any) error {
+	raw, err := s.Test(ctx, orgID, webhookID)
+	return decodeResult(raw, err, out)
+}
+
+// TestAll sends a test event to every enabled webhook of an organization,
+// at most a few at a time, and returns the results keyed by webhook ID.
+// A webhook whose test call itself fails still gets an entry, with Success
+// false and Error set; those failures are also returned together, joined
+// with errors.Join, each naming its webhook.
+func (s *WebhooksService) TestAll(ctx context.Context, orgID string) (map[string]*TestWebhookResponse, error) {
+	var webhooks []WebhookResponse
+	if err := s.ListInto(ctx, orgID, &webhooks); err != nil {
+		return nil, err
+	}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*TestWebhookResponse)
+		errs    []error
+		sem     = make(chan struct{}, testAllConcurrency)
+	)
+	for _, w := range webhooks {
+		if !w.IsEnabled {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() { <-sem; wg.Done() }()
+			var res TestWebhookResponse
+			err := s.TestInto(ctx, orgID, id, &res)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				msg := err.Error()
+				res = TestWebhookResponse{Error: &msg}
+				errs = append(errs, fmt.Errorf("webhook %s: %w", id, err))
+			}
+			results[id] = &res
+		}(w.ID)
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
 }
 
 // ListDeliveries returns delivery attempts for a webhook.
@@ -52,17 +135,41 @@ func (s *WebhooksService) ListDeliveries(ctx context.Context, orgID, webhookID s
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/deliveries", orgID, webhookID), params)
 }
 
+// ListDeliveriesInto is like ListDeliveries but decodes the response into out.
+func (s *WebhooksService) ListDeliveriesInto(ctx context.Context, orgID, webhookID string, params map[string]string, out any) error {
+	raw, err := s.ListDeliveries(ctx, orgID, webhookID, params)
+	return decodeResult(raw, err, out)
+}
+
 // GetDelivery retrieves a specific webhook delivery attempt.
 func (s *WebhooksService) GetDelivery(ctx context.Context, orgID, webhookID, deliveryID string) (json.RawMessage, error) {
 	return s.http.get(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/deliveries/%s", orgID, webhookID, deliveryID), nil)
 }
 
+// GetDeliveryInto is like GetDelivery but decodes the response into out.
+func (s *WebhooksService) GetDeliveryInto(ctx context.Context, orgID, webhookID, deliveryID string, out any) error {
+	raw, err := s.GetDelivery(ctx, orgID, webhookID, deliveryID)
+	return decodeResult(raw, err, out)
+}
+
 // RetryDelivery retries a failed webhook delivery.
 func (s *WebhooksService) RetryDelivery(ctx context.Context, orgID, webhookID, deliveryID string) (json.RawMessage, error) {
 	return s.http.post(ctx, fmt.Sprintf("/api/organizations/%s/webhooks/%s/deliveries/%s/retry", orgID, webhookID, deliveryID), nil)
 }
 
+// RetryDeliveryInto is like RetryDelivery but decodes the response into out.
+func (s *WebhooksService) RetryDeliveryInto(ctx context.Context, orgID, webhookID, deliveryID string, out any) error {
+	raw, err := s.RetryDelivery(ctx, orgID, webhookID, deliveryID)
+	return decodeResult(raw, err, out)
+}
+
 // ListEventTypes returns all available webhook event types.
 func (s *WebhooksService) ListEventTypes(ctx context.Context) (json.RawMessage, error) {
 	return s.http.get(ctx, "/api/webhooks/event-types", nil)
 }
+
+// ListEventTypesInto is like ListEventTypes but decodes the response into out.
+func (s *WebhooksService) ListEventTypesInto(ctx context.Context, out any) error {
+	raw, err := s.ListEventTypes(ctx)
+	return decodeResult(raw, err, out)
+}
@@ -0,0 +1,117 @@
+package coreauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every webhook delivery.
+const (
+	WebhookSignatureHeader = "X-CoreAuth-Signature"
+	WebhookTimestampHeader = "X-CoreAuth-Timestamp"
+	WebhookEventIDHeader   = "X-CoreAuth-Event-ID"
+	WebhookEventTypeHeader = "X-CoreAuth-Event-Type"
+)
+
+// DefaultWebhookTolerance is the replay window used when
+// VerifyWebhookSignatureOpts.Tolerance is zero.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// VerifyWebhookSignatureOpts configures VerifyWebhookSignature.
+type VerifyWebhookSignatureOpts struct {
+	// Tolerance is how far the delivery timestamp may be from Now before
+	// the webhook is rejected as a replay. Zero means
+	// DefaultWebhookTolerance; a negative value disables the check.
+	Tolerance time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// VerifyWebhookSignature checks that payload, the raw request body of a
+// webhook delivery, was signed by CoreAuth. signature and timestamp are the
+// values of the WebhookSignatureHeader and WebhookTimestampHeader headers.
+//
+// The signature is accepted if it matches any of secrets, so both the old
+// and the new secret can be passed while a rotation is rolling out. It
+// returns ErrWebhookSignatureMismatch if no secret matches and
+// ErrWebhookTimestampStale if the signature is valid but the timestamp is
+// outside the tolerance window. Malformed headers are reported as a
+// *ValidationError. opts may be nil.
+func VerifyWebhookSignature(payload []byte, signature, timestamp string, secrets []string, opts *VerifyWebhookSignatureOpts) error {
+	if opts == nil {
+		opts = &VerifyWebhookSignatureOpts{}
+	}
+	ts, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return &ValidationError{Field: "timestamp", Message: fmt.Sprintf("%q is not a unix timestamp", timestamp)}
+	}
+	sigHex, ok := strings.CutPrefix(strings.TrimSpace(signature), "sha256=")
+	if !ok {
+		return &ValidationError{Field: "signature", Message: `expected "sha256=<hex>"`}
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return &ValidationError{Field: "signature", Message: "signature is not valid hex"}
+	}
+	if len(secrets) == 0 {
+		return &ValidationError{Field: "secrets", Message: "at least one secret is required"}
+	}
+
+	matched := false
+	for _, secret := range secrets {
+		if secret != "" && hmac.Equal(sig, signWebhook(secret, timestamp, payload)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrWebhookSignatureMismatch
+	}
+
+	tolerance := opts.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	if tolerance > 0 {
+		now := time.Now
+		if opts.Now != nil {
+			now = opts.Now
+		}
+		age := now().Sub(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("%w: sent %s ago", ErrWebhookTimestampStale, age.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// VerifyWebhookRequest reads the body of an incoming webhook request and
+// verifies it with VerifyWebhookSignature. It returns the body so the
+// caller can decode it; the request body is consumed.
+func VerifyWebhookRequest(r *http.Request, secrets []string, opts *VerifyWebhookSignatureOpts) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, &CoreAuthError{Message: fmt.Sprintf("failed to read webhook body: %v", err), Err: err}
+	}
+	err = VerifyWebhookSignature(body, r.Header.Get(WebhookSignatureHeader), r.Header.Get(WebhookTimestampHeader), secrets, opts)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// signWebhook computes the HMAC-SHA256 of "<timestamp>.<payload>".
+func signWebhook(secret, timestamp string, payload []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return h.Sum(nil)
+}